@@ -13,23 +13,35 @@ import (
 
 // DoRequest executes a request with authentication
 func (c *Client) DoRequest(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	if body == nil {
+		return c.DoRawRequest(ctx, method, path, "", nil)
+	}
+
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return c.DoRawRequest(ctx, method, path, "application/json", jsonBody)
+}
+
+// DoRawRequest executes an authenticated request with a body that is sent as-is,
+// e.g. a zip archive. An empty contentType omits the Content-Type header.
+func (c *Client) DoRawRequest(ctx context.Context, method, path, contentType string, body []byte) ([]byte, error) {
 	var req *http.Request
 	var err error
 
 	url := fmt.Sprintf("%s%s", c.ApiURL, path)
 
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		req, err = http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(body))
 		if err != nil {
 			return nil, err
 		}
-		req, err = http.NewRequest(method, url, bytes.NewBuffer(jsonBody))
-		if err != nil {
-			return nil, err
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
 		}
-		req.Header.Set("Content-Type", "application/json")
 	} else {
-		req, err = http.NewRequest(method, url, nil)
+		req, err = http.NewRequestWithContext(ctx, method, url, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceACLTypes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceACLTypesRead,
+		Schema: map[string]*schema.Schema{
+			"include_resources": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to list the resources (components or routes) of every ACL type. The component list can be large.",
+			},
+			// Computed fields
+			"types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ACL type, used as type of appmixer_acl_rule.",
+						},
+						"actions": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Actions rules of this type can allow.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"resources": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Sorted resources rules of this type can match. Only populated when include_resources is true.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"rule_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of rules currently defined for this type.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func fetchACLStrings(ctx context.Context, client *Client, path string) ([]string, error) {
+	respBytes, err := client.DoRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var values []string
+	if err := json.Unmarshal(respBytes, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse %s response: %w", path, err)
+	}
+	return values, nil
+}
+
+func dataSourceACLTypesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	var diags diag.Diagnostics
+
+	if !hasAdminPermissions(client) {
+		return diag.Errorf("Reading ACL types requires admin permissions")
+	}
+
+	tflog.Debug(ctx, "Reading Appmixer ACL types data source")
+
+	names, err := fetchACLStrings(ctx, client, "/acl-types")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sort.Strings(names)
+
+	types := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		actions, err := fetchACLStrings(ctx, client, fmt.Sprintf("/acl/%s/actions", name))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		var resources []string
+		if d.Get("include_resources").(bool) {
+			if resources, err = fetchACLStrings(ctx, client, fmt.Sprintf("/acl/%s/resources", name)); err != nil {
+				return diag.FromErr(err)
+			}
+			sort.Strings(resources)
+		}
+
+		rules, err := fetchACLRules(ctx, client, name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		types = append(types, map[string]interface{}{
+			"name":       name,
+			"actions":    actions,
+			"resources":  resources,
+			"rule_count": len(rules),
+		})
+	}
+
+	d.SetId(fmt.Sprintf("acl-types-%d", len(types)))
+	if err := d.Set("types", types); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,202 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Vendor of the connectors shipped with Appmixer, anything else was published by the tenant
+const builtinAppVendor = "appmixer"
+
+func dataSourceApp() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAppRead,
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the app (e.g., appmixer.slack).",
+			},
+			"include_manifests": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to return the full manifest of every component in components. Otherwise only component_names is populated.",
+			},
+			"include_icon": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to include the base64 encoded icons of the app and its components.",
+			},
+			// Computed fields
+			"label": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"category": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"icon": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vendor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The vendor part of the app ID.",
+			},
+			"marketplace": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the app is offered in the Appmixer marketplace.",
+			},
+			"private": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the app is hidden from regular users.",
+			},
+			"custom_published": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the vendor of the app ID is not appmixer, i.e. the app was most likely published by the tenant rather than shipped with Appmixer. Derived from the app ID only.",
+			},
+			"modules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sorted modules of the app (e.g., appmixer.slack.list), derived from its component names.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"auth_services": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sorted auth services referenced by the components of the app.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"component_names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sorted names of all components of the app.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"components": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Full component manifests, sorted by name. Only populated when include_manifests is true.",
+				Elem:        componentManifestResource(),
+			},
+		},
+	}
+}
+
+// moduleOfComponent returns vendor.service.module for a vendor.service.module.Component name
+func moduleOfComponent(componentName string) string {
+	idx := strings.LastIndex(componentName, ".")
+	if idx <= 0 {
+		return componentName
+	}
+	return componentName[:idx]
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func dataSourceAppRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	appID := d.Get("app_id").(string)
+	includeIcon := d.Get("include_icon").(bool)
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Reading Appmixer app data source", map[string]interface{}{"app_id": appID})
+
+	respBytes, err := client.DoRequest(ctx, "GET", "/apps", nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to list apps: %w", err))
+	}
+
+	var appsData map[string]appResponse
+	if err := json.Unmarshal(respBytes, &appsData); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to parse apps response: %w", err))
+	}
+
+	app, ok := appsData[appID]
+	if !ok {
+		return diag.Errorf("App with ID %s not found", appID)
+	}
+
+	componentsData, err := fetchAppComponents(ctx, client, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sort.Slice(componentsData, func(i, j int) bool {
+		return componentsData[i].Name < componentsData[j].Name
+	})
+
+	modules := make(map[string]bool)
+	authServices := make(map[string]bool)
+	componentNames := make([]string, 0, len(componentsData))
+	components := make([]map[string]interface{}, 0, len(componentsData))
+	for _, comp := range componentsData {
+		componentNames = append(componentNames, comp.Name)
+		modules[moduleOfComponent(comp.Name)] = true
+
+		if service, ok := comp.Auth["service"].(string); ok && service != "" {
+			authServices[service] = true
+		}
+
+		if d.Get("include_manifests").(bool) {
+			if !includeIcon {
+				comp.Icon = ""
+			}
+			compMap, warnings := flattenComponentManifest(comp)
+			diags = append(diags, warnings...)
+			components = append(components, compMap)
+		}
+	}
+
+	vendor := strings.SplitN(appID, ".", 2)[0]
+	if !includeIcon {
+		app.Icon = ""
+	}
+
+	d.SetId(appID)
+	d.Set("label", app.Label)
+	d.Set("category", app.Category)
+	d.Set("description", app.Description)
+	d.Set("icon", app.Icon)
+	d.Set("version", app.Version)
+	d.Set("vendor", vendor)
+	d.Set("marketplace", app.Marketplace)
+	d.Set("private", app.Private)
+	d.Set("custom_published", vendor != builtinAppVendor)
+	d.Set("modules", sortedKeys(modules))
+	d.Set("auth_services", sortedKeys(authServices))
+	d.Set("component_names", componentNames)
+	if err := d.Set("components", components); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -5,16 +5,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-// Simplified representation of a component manifest for the data source
-// We'll flatten parts of it or encode complex nested parts as JSON strings
+// Representation of a component manifest (component.json). Ports and properties are kept
+// as raw JSON so they can be exposed verbatim; use the component* types to decode them.
 type componentManifest struct {
 	Name               string                 `json:"name"`
+	Label              string                 `json:"label,omitempty"`
+	Version            string                 `json:"version,omitempty"`
 	Author             string                 `json:"author,omitempty"`
 	Icon               string                 `json:"icon,omitempty"`
 	Description        string                 `json:"description,omitempty"`
@@ -24,144 +29,646 @@ type componentManifest struct {
 	Properties         json.RawMessage        `json:"properties,omitempty"` // Use RawMessage
 	Webhook            bool                   `json:"webhook,omitempty"`
 	WebhookAsync       bool                   `json:"webhookAsync,omitempty"`
-	HttpRequestMethods []string               `json:"httpRequestMethods,omitempty"`
+	HttpRequestMethods json.RawMessage        `json:"httpRequestMethods,omitempty"` // Decoded with decodeHTTPRequestMethods
 	State              map[string]interface{} `json:"state,omitempty"`
 	Private            bool                   `json:"private,omitempty"`
-	// Add other relevant fields if needed, potentially using json.RawMessage
+	Quota              json.RawMessage        `json:"quota,omitempty"` // Decoded with decodeComponentQuota
+	Tick               bool                   `json:"tick,omitempty"`
+	Marker             string                 `json:"marker,omitempty"`
+	FirePatterns       json.RawMessage        `json:"firePatterns,omitempty"`
+	Localization       json.RawMessage        `json:"localization,omitempty"`
 }
 
-func dataSourceAppComponents() *schema.Resource {
+// componentPort is a decoded in or out port of a component manifest.
+// Ports may be declared either as plain names or as objects.
+type componentPort struct {
+	Name           string                `json:"name"`
+	Label          string                `json:"label,omitempty"`
+	Schema         json.RawMessage       `json:"schema,omitempty"`
+	Inspector      *componentInspector   `json:"inspector,omitempty"`
+	Options        []componentPortOption `json:"options,omitempty"`
+	Source         json.RawMessage       `json:"source,omitempty"`
+	MaxConnections int                   `json:"maxConnections,omitempty"`
+}
+
+func (p *componentPort) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*p = componentPort{Name: name}
+		return nil
+	}
+
+	type portAlias componentPort
+	var alias portAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	*p = componentPort(alias)
+	return nil
+}
+
+// componentPortOption describes a variable offered by an out port
+type componentPortOption struct {
+	Label  string          `json:"label"`
+	Value  manifestString  `json:"value"`
+	Schema json.RawMessage `json:"schema,omitempty"`
+}
+
+// componentInspector describes the UI inputs of a port or of the component properties
+type componentInspector struct {
+	Inputs map[string]componentInspectorInput `json:"inputs,omitempty"`
+	Groups map[string]componentInspectorGroup `json:"groups,omitempty"`
+}
+
+type componentInspectorInput struct {
+	Type         string                     `json:"type"`
+	Label        string                     `json:"label,omitempty"`
+	Tooltip      string                     `json:"tooltip,omitempty"`
+	Index        manifestInt                `json:"index,omitempty"`
+	Group        string                     `json:"group,omitempty"`
+	DefaultValue json.RawMessage            `json:"defaultValue,omitempty"`
+	Options      []componentInspectorOption `json:"options,omitempty"`
+	Source       json.RawMessage            `json:"source,omitempty"`
+}
+
+// componentInspectorOption is a choice of a select-like inspector input, values can be of any JSON type
+type componentInspectorOption struct {
+	Label string          `json:"label"`
+	Value json.RawMessage `json:"value"`
+}
+
+type componentInspectorGroup struct {
+	Label string      `json:"label,omitempty"`
+	Index manifestInt `json:"index,omitempty"`
+	Open  bool        `json:"open,omitempty"`
+}
+
+// componentProperties is the decoded properties section of a component manifest
+type componentProperties struct {
+	Schema    json.RawMessage     `json:"schema,omitempty"`
+	Inspector *componentInspector `json:"inspector,omitempty"`
+}
+
+// componentQuota references the quota module limiting calls of a component
+type componentQuota struct {
+	Manager   manifestString         `json:"manager"`
+	Resources stringOrList           `json:"resources,omitempty"`
+	Scope     map[string]interface{} `json:"scope,omitempty"`
+}
+
+// decodeComponentQuota decodes the quota section of a manifest, nil when it is not set
+func decodeComponentQuota(raw json.RawMessage) (*componentQuota, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var quota componentQuota
+	if err := json.Unmarshal(raw, &quota); err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
+
+// decodeHTTPRequestMethods decodes the httpRequestMethods of a manifest, written as
+// a single method or a list of methods
+func decodeHTTPRequestMethods(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var methods stringOrList
+	if err := json.Unmarshal(raw, &methods); err != nil {
+		return nil, err
+	}
+	return methods, nil
+}
+
+// stringOrList decodes manifest values that may be a single string or a list of strings
+type stringOrList []string
+
+func (s *stringOrList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = stringOrList{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*s = list
+	return nil
+}
+
+// manifestInt decodes numbers written as integers, floats or numeric strings, as found
+// in hand written manifests. Other values decode as 0.
+type manifestInt int
+
+func (i *manifestInt) UnmarshalJSON(data []byte) error {
+	var number float64
+	if err := json.Unmarshal(data, &number); err == nil {
+		*i = manifestInt(number)
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		if number, err := strconv.ParseFloat(strings.TrimSpace(text), 64); err == nil {
+			*i = manifestInt(number)
+			return nil
+		}
+	}
+	*i = 0
+	return nil
+}
+
+// manifestString decodes strings as they are and any other JSON value as its JSON text
+type manifestString string
+
+func (s *manifestString) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*s = manifestString(text)
+		return nil
+	}
+	if string(data) == "null" {
+		*s = ""
+		return nil
+	}
+	*s = manifestString(data)
+	return nil
+}
+
+// flattenManifestValues converts a manifest section into a string map, JSON encoding
+// values that are not strings
+func flattenManifestValues(values map[string]interface{}) map[string]string {
+	result := make(map[string]string, len(values))
+	for k, v := range values {
+		if text, ok := v.(string); ok {
+			result[k] = text
+			continue
+		}
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			continue
+		}
+		result[k] = string(encoded)
+	}
+	return result
+}
+
+// requiredFields returns the names listed in the "required" keyword of a JSON schema
+func requiredFields(rawSchema json.RawMessage) []string {
+	if len(rawSchema) == 0 {
+		return []string{}
+	}
+
+	var schemaDef struct {
+		Required []string `json:"required"`
+	}
+	if err := json.Unmarshal(rawSchema, &schemaDef); err != nil || schemaDef.Required == nil {
+		return []string{}
+	}
+	return schemaDef.Required
+}
+
+// flattenInspectorInputs converts inspector inputs into a list ordered by index, then name
+func flattenInspectorInputs(inspector *componentInspector, required []string) []interface{} {
+	if inspector == nil {
+		return []interface{}{}
+	}
+
+	requiredSet := make(map[string]bool, len(required))
+	for _, name := range required {
+		requiredSet[name] = true
+	}
+
+	names := make([]string, 0, len(inspector.Inputs))
+	for name := range inspector.Inputs {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := inspector.Inputs[names[i]], inspector.Inputs[names[j]]
+		if a.Index != b.Index {
+			return a.Index < b.Index
+		}
+		return names[i] < names[j]
+	})
+
+	fields := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		input := inspector.Inputs[name]
+		fields = append(fields, map[string]interface{}{
+			"name":         name,
+			"type":         input.Type,
+			"label":        input.Label,
+			"tooltip":      input.Tooltip,
+			"index":        int(input.Index),
+			"group":        input.Group,
+			"required":     requiredSet[name],
+			"default_json": string(input.DefaultValue),
+		})
+	}
+	return fields
+}
+
+func flattenComponentPorts(rawPorts json.RawMessage) ([]interface{}, error) {
+	if len(rawPorts) == 0 {
+		return []interface{}{}, nil
+	}
+
+	var ports []componentPort
+	if err := json.Unmarshal(rawPorts, &ports); err != nil {
+		return nil, err
+	}
+
+	result := make([]interface{}, 0, len(ports))
+	for _, port := range ports {
+		required := requiredFields(port.Schema)
+
+		options := make([]interface{}, 0, len(port.Options))
+		for _, opt := range port.Options {
+			options = append(options, map[string]interface{}{
+				"label":       opt.Label,
+				"value":       string(opt.Value),
+				"schema_json": string(opt.Schema),
+			})
+		}
+
+		result = append(result, map[string]interface{}{
+			"name":             port.Name,
+			"schema_json":      string(port.Schema),
+			"required_fields":  required,
+			"inspector_fields": flattenInspectorInputs(port.Inspector, required),
+			"options":          options,
+		})
+	}
+	return result, nil
+}
+
+func flattenComponentProperties(rawProperties json.RawMessage) ([]interface{}, error) {
+	if len(rawProperties) == 0 {
+		return []interface{}{}, nil
+	}
+
+	var props componentProperties
+	if err := json.Unmarshal(rawProperties, &props); err != nil {
+		return nil, err
+	}
+
+	required := requiredFields(props.Schema)
+	return []interface{}{
+		map[string]interface{}{
+			"schema_json":      string(props.Schema),
+			"required_fields":  required,
+			"inspector_fields": flattenInspectorInputs(props.Inspector, required),
+		},
+	}, nil
+}
+
+func flattenComponentAuth(auth map[string]interface{}) []interface{} {
+	if auth == nil {
+		return []interface{}{}
+	}
+
+	authInfo := map[string]interface{}{
+		"service": "",
+		"type":    "",
+		"scope":   []string{},
+	}
+	if service, ok := auth["service"].(string); ok {
+		authInfo["service"] = service
+	}
+	if authType, ok := auth["type"].(string); ok {
+		authInfo["type"] = authType
+	}
+	if scopes, ok := auth["scope"].([]interface{}); ok {
+		scope := make([]string, 0, len(scopes))
+		for _, v := range scopes {
+			scope = append(scope, fmt.Sprintf("%v", v))
+		}
+		authInfo["scope"] = scope
+	}
+	return []interface{}{authInfo}
+}
+
+func flattenComponentQuota(quota *componentQuota) []interface{} {
+	if quota == nil {
+		return []interface{}{}
+	}
+
+	resources := []string(quota.Resources)
+	if resources == nil {
+		resources = []string{}
+	}
+	scope := flattenManifestValues(quota.Scope)
+	return []interface{}{
+		map[string]interface{}{
+			"manager":   string(quota.Manager),
+			"resources": resources,
+			"scope":     scope,
+		},
+	}
+}
+
+func inspectorFieldsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Inspector inputs, ordered by their index.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name":         {Type: schema.TypeString, Computed: true},
+				"type":         {Type: schema.TypeString, Computed: true},
+				"label":        {Type: schema.TypeString, Computed: true},
+				"tooltip":      {Type: schema.TypeString, Computed: true},
+				"index":        {Type: schema.TypeInt, Computed: true},
+				"group":        {Type: schema.TypeString, Computed: true},
+				"required":     {Type: schema.TypeBool, Computed: true, Description: "Whether the field is listed as required in the JSON schema."},
+				"default_json": {Type: schema.TypeString, Computed: true, Description: "JSON encoded default value of the field."},
+			},
+		},
+	}
+}
+
+func componentPortsSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"schema_json": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "JSON schema of the messages accepted by the port.",
+				},
+				"required_fields": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"inspector_fields": inspectorFieldsSchema(),
+				"options": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "Variables offered by an out port.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"label":       {Type: schema.TypeString, Computed: true},
+							"value":       {Type: schema.TypeString, Computed: true},
+							"schema_json": {Type: schema.TypeString, Computed: true},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// componentManifestResource is the schema of a single component, shared by the
+// appmixer_app_components and appmixer_app data sources
+func componentManifestResource() *schema.Resource {
 	return &schema.Resource{
-		ReadContext: dataSourceAppComponentsRead,
 		Schema: map[string]*schema.Schema{
-			"app_id": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The ID of the app (e.g., appmixer.dropbox) whose components are to be retrieved.",
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
 			},
-			"components": {
+			"author": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"icon": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"auth": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString}, // Simplified
+			},
+			"in_ports_json": { // Store complex parts as JSON strings
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"out_ports_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"properties_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"webhook": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"webhook_async": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"http_request_methods": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"state": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString}, // Simplified
+			},
+			"private": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"in_ports":  componentPortsSchema("Decoded input ports of the component."),
+			"out_ports": componentPortsSchema("Decoded output ports of the component."),
+			"properties": {
 				Type:        schema.TypeList,
 				Computed:    true,
-				Description: "List of components available for the specified app, including their manifest details.",
+				Description: "Decoded configuration properties of the component.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"name": {
+						"schema_json": {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
-						"author": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"icon": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"description": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"auth": {
-							Type:     schema.TypeMap,
-							Computed: true,
-							Elem:     &schema.Schema{Type: schema.TypeString}, // Simplified
-						},
-						"in_ports_json": { // Store complex parts as JSON strings
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"out_ports_json": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"properties_json": {
-							Type:     schema.TypeString,
-							Computed: true,
-						},
-						"webhook": {
-							Type:     schema.TypeBool,
+						"required_fields": {
+							Type:     schema.TypeList,
 							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
 						},
-						"webhook_async": {
-							Type:     schema.TypeBool,
+						"inspector_fields": inspectorFieldsSchema(),
+					},
+				},
+			},
+			"auth_info": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Authentication requirements of the component.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service": {Type: schema.TypeString, Computed: true},
+						"type":    {Type: schema.TypeString, Computed: true},
+						"scope": {
+							Type:     schema.TypeList,
 							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
 						},
-						"http_request_methods": {
+					},
+				},
+			},
+			"quota": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Quota module limiting the calls of the component.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"manager": {Type: schema.TypeString, Computed: true},
+						"resources": {
 							Type:     schema.TypeList,
 							Computed: true,
 							Elem:     &schema.Schema{Type: schema.TypeString},
 						},
-						"state": {
+						"scope": {
 							Type:     schema.TypeMap,
 							Computed: true,
-							Elem:     &schema.Schema{Type: schema.TypeString}, // Simplified
-						},
-						"private": {
-							Type:     schema.TypeBool,
-							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
 						},
-						// Add other fields as needed
 					},
 				},
 			},
+			// Add other fields as needed
 		},
 	}
 }
 
-func dataSourceAppComponentsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*Client)
-	appID := d.Get("app_id").(string)
+// flattenComponentManifest converts a component manifest into the componentManifestResource
+// representation. Sections that cannot be decoded are left empty and reported as warnings,
+// their raw JSON is still available.
+func flattenComponentManifest(comp componentManifest) (map[string]interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
+	decodeWarning := func(section string, err error) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Failed to decode %s of component %s", section, comp.Name),
+			Detail:   fmt.Sprintf("The decoded %s are left empty, the raw JSON is kept: %s", section, err),
+		})
+	}
+
+	inPorts, err := flattenComponentPorts(comp.InPorts)
+	if err != nil {
+		decodeWarning("inPorts", err)
+		inPorts = []interface{}{}
+	}
+	outPorts, err := flattenComponentPorts(comp.OutPorts)
+	if err != nil {
+		decodeWarning("outPorts", err)
+		outPorts = []interface{}{}
+	}
+	properties, err := flattenComponentProperties(comp.Properties)
+	if err != nil {
+		decodeWarning("properties", err)
+		properties = []interface{}{}
+	}
+	methods, err := decodeHTTPRequestMethods(comp.HttpRequestMethods)
+	if err != nil {
+		decodeWarning("httpRequestMethods", err)
+	}
+	if methods == nil {
+		methods = []string{}
+	}
+	quota, err := decodeComponentQuota(comp.Quota)
+	if err != nil {
+		decodeWarning("quota", err)
+	}
+
+	return map[string]interface{}{
+		"name":                 comp.Name,
+		"author":               comp.Author,
+		"icon":                 comp.Icon,
+		"description":          comp.Description,
+		"auth":                 flattenManifestValues(comp.Auth),
+		"in_ports_json":        string(comp.InPorts),
+		"out_ports_json":       string(comp.OutPorts),
+		"properties_json":      string(comp.Properties),
+		"webhook":              comp.Webhook,
+		"webhook_async":        comp.WebhookAsync,
+		"http_request_methods": methods,
+		"state":                flattenManifestValues(comp.State),
+		"private":              comp.Private,
+		"in_ports":             inPorts,
+		"out_ports":            outPorts,
+		"properties":           properties,
+		"auth_info":            flattenComponentAuth(comp.Auth),
+		"quota":                flattenComponentQuota(quota),
+	}, diags
+}
+
+func dataSourceAppComponents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAppComponentsRead,
+		Schema: map[string]*schema.Schema{
+			"app_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the app (e.g., appmixer.dropbox) whose components are to be retrieved.",
+			},
+			"components": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of components available for the specified app, including their manifest details.",
+				Elem:        componentManifestResource(),
+			},
+		},
+	}
+}
 
-	apiPath := "/apps/components"
+// fetchAppComponents returns the manifests of all components of an app
+func fetchAppComponents(ctx context.Context, client *Client, appID string) ([]componentManifest, error) {
 	queryParams := url.Values{}
 	queryParams.Add("app", appID)
-	apiPath = fmt.Sprintf("%s?%s", apiPath, queryParams.Encode())
-
-	tflog.Debug(ctx, "Reading Appmixer app components data source", map[string]interface{}{"app_id": appID, "path": apiPath})
+	apiPath := fmt.Sprintf("/apps/components?%s", queryParams.Encode())
 
 	respBytes, err := client.DoRequest(ctx, "GET", apiPath, nil)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("failed to list components for app %s: %w", appID, err))
+		return nil, fmt.Errorf("failed to list components for app %s: %w", appID, err)
 	}
 
 	var componentsData []componentManifest
 	if err := json.Unmarshal(respBytes, &componentsData); err != nil {
-		return diag.FromErr(fmt.Errorf("failed to parse components response for app %s: %w", appID, err))
+		return nil, fmt.Errorf("failed to parse components response for app %s: %w", appID, err)
 	}
 
-	components := make([]map[string]interface{}, len(componentsData))
-	for i, comp := range componentsData {
+	return componentsData, nil
+}
 
-		authMap := make(map[string]string)
-		if comp.Auth != nil {
-			for k, v := range comp.Auth {
-				authMap[k] = fmt.Sprintf("%v", v) // Convert auth values to strings
-			}
-		}
+func dataSourceAppComponentsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	appID := d.Get("app_id").(string)
+	var diags diag.Diagnostics
 
-		stateMap := make(map[string]string)
-		if comp.State != nil {
-			for k, v := range comp.State {
-				stateMap[k] = fmt.Sprintf("%v", v) // Convert state values to strings
-			}
-		}
+	tflog.Debug(ctx, "Reading Appmixer app components data source", map[string]interface{}{"app_id": appID})
 
-		compMap := map[string]interface{}{
-			"name":                 comp.Name,
-			"author":               comp.Author,
-			"icon":                 comp.Icon,
-			"description":          comp.Description,
-			"auth":                 authMap,
-			"in_ports_json":        string(comp.InPorts),
-			"out_ports_json":       string(comp.OutPorts),
-			"properties_json":      string(comp.Properties),
-			"webhook":              comp.Webhook,
-			"webhook_async":        comp.WebhookAsync,
-			"http_request_methods": comp.HttpRequestMethods,
-			"state":                stateMap,
-			"private":              comp.Private,
-		}
+	componentsData, err := fetchAppComponents(ctx, client, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	components := make([]map[string]interface{}, len(componentsData))
+	for i, comp := range componentsData {
+		compMap, warnings := flattenComponentManifest(comp)
+		diags = append(diags, warnings...)
 		components[i] = compMap
 	}
 
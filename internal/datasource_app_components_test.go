@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFlattenComponentManifestLenient(t *testing.T) {
+	var comp componentManifest
+	if err := json.Unmarshal([]byte(`{
+		"name": "vendor.service.module.Component",
+		"webhook": true,
+		"httpRequestMethods": [{"method": "POST"}],
+		"quota": "vendor.service.quota"
+	}`), &comp); err != nil {
+		t.Fatalf("an unusual manifest failed to decode: %s", err)
+	}
+
+	flattened, diags := flattenComponentManifest(comp)
+	if diags.HasError() {
+		t.Fatalf("got errors: %v", diags)
+	}
+	if len(diags) != 2 {
+		t.Errorf("got %d warnings, want one for httpRequestMethods and one for quota: %v", len(diags), diags)
+	}
+	if got := flattened["http_request_methods"].([]string); len(got) != 0 {
+		t.Errorf("http_request_methods is %v, want it empty", got)
+	}
+	if got := flattened["quota"].([]interface{}); len(got) != 0 {
+		t.Errorf("quota is %v, want it empty", got)
+	}
+}
+
+func TestFlattenComponentManifestSingleMethod(t *testing.T) {
+	var comp componentManifest
+	if err := json.Unmarshal([]byte(`{
+		"name": "vendor.service.module.Component",
+		"httpRequestMethods": "POST",
+		"quota": {"manager": "vendor.service.quota", "resources": "messages"}
+	}`), &comp); err != nil {
+		t.Fatal(err)
+	}
+
+	flattened, diags := flattenComponentManifest(comp)
+	if len(diags) != 0 {
+		t.Errorf("got diagnostics: %v", diags)
+	}
+	if got := flattened["http_request_methods"].([]string); !reflect.DeepEqual(got, []string{"POST"}) {
+		t.Errorf("http_request_methods is %v, want [POST]", got)
+	}
+	quota := flattened["quota"].([]interface{})[0].(map[string]interface{})
+	if quota["manager"] != "vendor.service.quota" || !reflect.DeepEqual(quota["resources"], []string{"messages"}) {
+		t.Errorf("quota is %v", quota)
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Represents the structure of a single app from the GET /apps response
@@ -18,18 +21,42 @@ type appResponse struct {
 	Label       string `json:"label"`
 	Category    string `json:"category"`
 	Description string `json:"description"`
-	Icon        string `json:"icon"`
+	Icon        string `json:"icon,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Marketplace bool   `json:"marketplace,omitempty"`
+	Private     bool   `json:"private,omitempty"`
 }
 
 func dataSourceApps() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceAppsRead,
 		Schema: map[string]*schema.Schema{
-			// No input arguments needed for GET /apps
+			"category": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return applications of this category.",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Only return applications whose name (e.g., appmixer.asana) matches this regular expression.",
+			},
+			"label": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return applications with this label.",
+			},
+			"include_icons": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to include the base64 encoded icons. Disable for large catalogs to keep them out of the state.",
+			},
 			"apps": {
 				Type:        schema.TypeList,
 				Computed:    true,
-				Description: "List of available applications (services/modules).",
+				Description: "List of available applications (services/modules), sorted by name.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
@@ -55,11 +82,17 @@ func dataSourceApps() *schema.Resource {
 						"icon": {
 							Type:        schema.TypeString,
 							Computed:    true,
-							Description: "Base64 encoded icon for the application.",
+							Description: "Base64 encoded icon for the application. Empty when include_icons is false.",
 						},
 					},
 				},
 			},
+			"apps_by_id": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The same applications keyed by their name/ID. Values are JSON encoded application objects without the icon, use jsondecode() to access their attributes.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -81,22 +114,65 @@ func dataSourceAppsRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(fmt.Errorf("failed to parse apps response: %w", err))
 	}
 
-	// Convert the map to a list for Terraform
-	apps := make([]map[string]interface{}, 0, len(appsData))
+	category := d.Get("category").(string)
+	label := d.Get("label").(string)
+	includeIcons := d.Get("include_icons").(bool)
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+
+	// Go map iteration order is random, sort the names to keep the list stable between refreshes
+	appNames := make([]string, 0, len(appsData))
 	for appName, appDetails := range appsData {
+		if category != "" && appDetails.Category != category {
+			continue
+		}
+		if label != "" && appDetails.Label != label {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(appName) {
+			continue
+		}
+		appNames = append(appNames, appName)
+	}
+	sort.Strings(appNames)
+
+	// Convert the map to a list for Terraform
+	apps := make([]map[string]interface{}, 0, len(appNames))
+	appsByID := make(map[string]string, len(appNames))
+	for _, appName := range appNames {
+		appDetails := appsData[appName]
+		appDetails.Name = appName // Use the map key as the name
+		if !includeIcons {
+			appDetails.Icon = ""
+		}
+
 		appMap := map[string]interface{}{
-			"name":        appName, // Use the map key as the name
+			"name":        appName,
 			"label":       appDetails.Label,
 			"category":    appDetails.Category,
 			"description": appDetails.Description,
 			"icon":        appDetails.Icon,
 		}
 		apps = append(apps, appMap)
+
+		// Icons are only kept once in state, in apps
+		appDetails.Icon = ""
+		appJSON, err := json.Marshal(appDetails)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to encode app %s: %w", appName, err))
+		}
+		appsByID[appName] = string(appJSON)
 	}
 
 	if err := d.Set("apps", apps); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set("apps_by_id", appsByID); err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Set a static ID for this data source, as it represents a collection
 	d.SetId("appmixer-available-apps")
@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceComponentManifest() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceComponentManifestRead,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"path", "content"},
+				Description:  "Path to a local component.json file.",
+			},
+			"content": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"path", "content"},
+				Description:  "JSON content of a component manifest.",
+			},
+			"known_auth_services": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Auth services (e.g., 'vendor:service') the manifest may reference. When empty, auth references are only checked for their format.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"fail_on_error": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether validation errors fail the data source. When false, they are only reported in errors.",
+			},
+			// Computed fields
+			"valid": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the manifest has no validation errors.",
+			},
+			"errors": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Validation errors, each prefixed with the path of the offending manifest field.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"warnings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Validation warnings, each prefixed with the path of the offending manifest field.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"label": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"webhook": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"tick": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"in_ports":  componentPortsSchema("Decoded input ports of the component."),
+			"out_ports": componentPortsSchema("Decoded output ports of the component."),
+		},
+	}
+}
+
+func dataSourceComponentManifestRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	source := "content"
+	content := []byte(d.Get("content").(string))
+	if p, ok := d.GetOk("path"); ok {
+		source = p.(string)
+		var err error
+		content, err = os.ReadFile(source)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to read component manifest %s: %w", source, err))
+		}
+	}
+
+	tflog.Debug(ctx, "Validating component manifest", map[string]interface{}{"source": source})
+
+	manifest, issues := validateComponentManifest(content, expandStringList(d.Get("known_auth_services")))
+	errs, warnings := splitManifestIssues(issues)
+
+	if len(errs) > 0 && d.Get("fail_on_error").(bool) {
+		for _, e := range errs {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Invalid component manifest",
+				Detail:   fmt.Sprintf("%s: %s", source, e),
+			})
+		}
+		return diags
+	}
+
+	d.Set("valid", len(errs) == 0)
+	d.Set("errors", errs)
+	d.Set("warnings", warnings)
+
+	if manifest != nil {
+		d.Set("name", manifest.Name)
+		d.Set("label", manifest.Label)
+		d.Set("description", manifest.Description)
+		d.Set("version", manifest.Version)
+		d.Set("webhook", manifest.Webhook)
+		d.Set("tick", manifest.Tick)
+
+		// Port decoding errors are already reported by the validator
+		if inPorts, err := flattenComponentPorts(manifest.InPorts); err == nil {
+			d.Set("in_ports", inPorts)
+		}
+		if outPorts, err := flattenComponentPorts(manifest.OutPorts); err == nil {
+			d.Set("out_ports", outPorts)
+		}
+	}
+
+	sum := sha256.Sum256(content)
+	d.SetId(hex.EncodeToString(sum[:]))
+
+	return diags
+}
@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// pageDataStoreItems applies limit and offset to items. A limit of 0 returns all items
+// from offset on.
+func pageDataStoreItems(items []dataStoreItem, limit, offset int) []dataStoreItem {
+	if offset >= len(items) {
+		return []dataStoreItem{}
+	}
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+func dataSourceDataStoreItems() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDataStoreItemsRead,
+		Schema: map[string]*schema.Schema{
+			"store_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the data store.",
+			},
+			"pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return items whose key matches the pattern.",
+			},
+			"keys": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Only return items with these exact keys. The whole data store is read to find them, limit and offset apply to the matching items.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"sort": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Sort items (e.g., 'key:1' or 'updatedAt:-1').",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Limit the number of items returned. 0 returns all items.",
+			},
+			"offset": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Offset for pagination",
+			},
+			// Computed fields
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total number of items in the data store, independent of filters and pagination.",
+			},
+			"values": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "JSON encoded values of the returned items, keyed by item key.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"items": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The JSON encoded value of the item.",
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDataStoreItemsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	storeID := d.Get("store_id").(string)
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Reading Appmixer data store items data source", map[string]interface{}{
+		"store_id": storeID,
+	})
+
+	wantedKeys := make(map[string]bool)
+	for _, key := range expandStringList(d.Get("keys")) {
+		wantedKeys[key] = true
+	}
+
+	// The API cannot filter by exact keys, so page through the whole store and apply
+	// limit and offset to the matching items
+	limit, offset := d.Get("limit").(int), d.Get("offset").(int)
+	if len(wantedKeys) > 0 {
+		limit, offset = 0, 0
+	}
+	items, err := listDataStoreItems(ctx, client, storeID, d.Get("pattern").(string), d.Get("sort").(string), limit, offset)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(wantedKeys) > 0 {
+		matching := make([]dataStoreItem, 0, len(wantedKeys))
+		for _, item := range items {
+			if wantedKeys[item.Key] {
+				matching = append(matching, item)
+			}
+		}
+		items = pageDataStoreItems(matching, d.Get("limit").(int), d.Get("offset").(int))
+	}
+
+	respBytes, err := client.DoRequest(ctx, "GET", "/store/count?storeId="+url.QueryEscape(storeID), nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to count items of data store %s: %w", storeID, err))
+	}
+	var countRes struct {
+		Count int `json:"count"`
+	}
+	if err := json.Unmarshal(respBytes, &countRes); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to parse item count of data store %s: %w", storeID, err))
+	}
+
+	itemList := make([]map[string]interface{}, 0, len(items))
+	values := make(map[string]string, len(items))
+	for _, item := range items {
+		itemList = append(itemList, map[string]interface{}{
+			"key":        item.Key,
+			"value":      string(item.Value),
+			"created_at": item.CreatedAt,
+			"updated_at": item.UpdatedAt,
+		})
+		values[item.Key] = string(item.Value)
+	}
+
+	d.SetId(fmt.Sprintf("store-%s-items-%d", storeID, len(itemList)))
+	d.Set("total", countRes.Count)
+	d.Set("values", values)
+	if err := d.Set("items", itemList); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
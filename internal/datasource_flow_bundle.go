@@ -0,0 +1,147 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceFlowBundle() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFlowBundleRead,
+		Schema: map[string]*schema.Schema{
+			"bundle": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "A flow bundle produced by the appmixer_flow_export data source, e.g. from another tenant.",
+			},
+			"account_map": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Account IDs of the source tenant mapped to account IDs of this tenant.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"store_map": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Data store IDs of the source tenant mapped to data store IDs of this tenant.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"user_map": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "User IDs of the source tenant mapped to user IDs of this tenant.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			// Computed fields
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the exported flow.",
+			},
+			"descriptor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The JSON encoded flow descriptor with all references remapped.",
+			},
+			"custom_fields": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The JSON encoded custom fields of the exported flow.",
+			},
+			"redacted": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Paths of config properties removed on export that must be set again.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func parseFlowBundle(data string) (*flowBundle, error) {
+	var bundle flowBundle
+	if err := json.Unmarshal([]byte(data), &bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse flow bundle: %w", err)
+	}
+	if bundle.Format != flowBundleFormat {
+		return nil, fmt.Errorf("not a flow bundle, format is %q instead of %q", bundle.Format, flowBundleFormat)
+	}
+	if bundle.Version != flowBundleVersion {
+		return nil, fmt.Errorf("unsupported flow bundle version %d, expected %d", bundle.Version, flowBundleVersion)
+	}
+	if bundle.Descriptor == nil {
+		return nil, fmt.Errorf("flow bundle has no descriptor")
+	}
+	return &bundle, nil
+}
+
+// remapFlowBundle replaces every reference of the descriptor using the maps of each kind and
+// returns the paths of references without a mapping
+func remapFlowBundle(bundle *flowBundle, maps map[string]map[string]interface{}) []string {
+	var unmapped []string
+	walkFlowReferences(bundle.Descriptor, func(kind, path, value string) string {
+		if mapped, ok := maps[kind][value].(string); ok && mapped != "" {
+			return mapped
+		}
+		unmapped = append(unmapped, fmt.Sprintf("%s (%s %s)", path, kind, value))
+		return value
+	})
+	sort.Strings(unmapped)
+	return unmapped
+}
+
+func dataSourceFlowBundleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	bundle, err := parseFlowBundle(d.Get("bundle").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, "Remapping Appmixer flow bundle", map[string]interface{}{
+		"name":     bundle.Name,
+		"accounts": len(bundle.References.Accounts),
+		"stores":   len(bundle.References.Stores),
+		"users":    len(bundle.References.Users),
+	})
+
+	unmapped := remapFlowBundle(bundle, map[string]map[string]interface{}{
+		"account": d.Get("account_map").(map[string]interface{}),
+		"store":   d.Get("store_map").(map[string]interface{}),
+		"user":    d.Get("user_map").(map[string]interface{}),
+	})
+	if len(unmapped) > 0 {
+		return diag.Errorf("Flow bundle %q has references without a mapping:\n  %s", bundle.Name, strings.Join(unmapped, "\n  "))
+	}
+
+	descriptor, err := json.Marshal(bundle.Descriptor)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to encode descriptor: %w", err))
+	}
+	customFields := []byte("{}")
+	if bundle.CustomFields != nil {
+		if customFields, err = json.Marshal(bundle.CustomFields); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to encode custom fields: %w", err))
+		}
+	}
+
+	sum := sha256.Sum256(descriptor)
+	d.SetId(hex.EncodeToString(sum[:]))
+	d.Set("name", bundle.Name)
+	d.Set("descriptor", string(descriptor))
+	d.Set("custom_fields", string(customFields))
+	d.Set("redacted", bundle.Redacted)
+
+	return diags
+}
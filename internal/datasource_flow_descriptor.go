@@ -0,0 +1,391 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// flowComponentSpec is a component block of the flow descriptor data source
+type flowComponentSpec struct {
+	ID         string
+	Label      string
+	Type       string
+	Properties map[string]interface{}
+	AccountID  string
+	X          int
+	Y          int
+}
+
+// flowLinkSpec is a link block of the flow descriptor data source
+type flowLinkSpec struct {
+	From     string
+	FromPort string
+	To       string
+	ToPort   string
+}
+
+func dataSourceFlowDescriptor() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFlowDescriptorRead,
+		Schema: map[string]*schema.Schema{
+			"component": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Components of the flow.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"label": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Unique label of the component in the flow, referenced by links.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The component type (e.g., 'appmixer.utils.controls.OnStart').",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "The component ID in the descriptor. Derived from the label when not set, so it stays stable between runs.",
+						},
+						"properties": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsJSON,
+							Description:  "JSON encoded object of the component config properties.",
+						},
+						"account_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The account bound to the component.",
+						},
+						"x": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  0,
+						},
+						"y": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  0,
+						},
+					},
+				},
+			},
+			"link": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Links between components.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"from": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Label of the source component.",
+						},
+						"from_port": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "out",
+							Description: "Output port of the source component.",
+						},
+						"to": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Label of the target component.",
+						},
+						"to_port": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "in",
+							Description: "Input port of the target component.",
+						},
+					},
+				},
+			},
+			"validate_manifests": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Validate component types, ports, required properties and accounts against the manifests from /apps/components.",
+			},
+			// Computed fields
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The rendered flow descriptor.",
+			},
+			"component_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Component IDs in the descriptor keyed by label.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// componentIDForLabel derives a UUID formatted component ID from a label
+func componentIDForLabel(label string) string {
+	sum := sha1.Sum([]byte("appmixer-flow-component:" + label))
+	sum[6] = (sum[6] & 0x0f) | 0x50 // version 5
+	sum[8] = (sum[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+func expandFlowComponents(v interface{}) ([]flowComponentSpec, error) {
+	raw, _ := v.([]interface{})
+	components := make([]flowComponentSpec, 0, len(raw))
+	labels := make(map[string]bool)
+
+	for i, item := range raw {
+		c := item.(map[string]interface{})
+		spec := flowComponentSpec{
+			ID:        c["id"].(string),
+			Label:     c["label"].(string),
+			Type:      c["type"].(string),
+			AccountID: c["account_id"].(string),
+			X:         c["x"].(int),
+			Y:         c["y"].(int),
+		}
+
+		if labels[spec.Label] {
+			return nil, fmt.Errorf("component[%d]: duplicate label %q", i, spec.Label)
+		}
+		labels[spec.Label] = true
+
+		if spec.ID == "" {
+			spec.ID = componentIDForLabel(spec.Label)
+		}
+
+		spec.Properties = map[string]interface{}{}
+		if props := c["properties"].(string); props != "" {
+			if err := json.Unmarshal([]byte(props), &spec.Properties); err != nil {
+				return nil, fmt.Errorf("component %q: properties must be a JSON object: %w", spec.Label, err)
+			}
+		}
+
+		components = append(components, spec)
+	}
+	return components, nil
+}
+
+func expandFlowLinks(v interface{}) []flowLinkSpec {
+	raw, _ := v.([]interface{})
+	links := make([]flowLinkSpec, 0, len(raw))
+	for _, item := range raw {
+		l := item.(map[string]interface{})
+		links = append(links, flowLinkSpec{
+			From:     l["from"].(string),
+			FromPort: l["from_port"].(string),
+			To:       l["to"].(string),
+			ToPort:   l["to_port"].(string),
+		})
+	}
+	return links
+}
+
+// renderFlowDescriptor builds the descriptor keyed by component ID. Every link adds the
+// source component and port to the source of the target input port.
+func renderFlowDescriptor(components []flowComponentSpec, links []flowLinkSpec) (map[string]interface{}, error) {
+	byLabel := make(map[string]flowComponentSpec, len(components))
+	descriptor := make(map[string]interface{}, len(components))
+	for _, c := range components {
+		byLabel[c.Label] = c
+		node := map[string]interface{}{
+			"type":   c.Type,
+			"label":  c.Label,
+			"x":      c.X,
+			"y":      c.Y,
+			"source": map[string]interface{}{},
+			"config": map[string]interface{}{
+				"properties": c.Properties,
+			},
+		}
+		if c.AccountID != "" {
+			node["accountId"] = c.AccountID
+		}
+		descriptor[c.ID] = node
+	}
+
+	for i, l := range links {
+		from, ok := byLabel[l.From]
+		if !ok {
+			return nil, fmt.Errorf("link[%d]: unknown source component %q", i, l.From)
+		}
+		to, ok := byLabel[l.To]
+		if !ok {
+			return nil, fmt.Errorf("link[%d]: unknown target component %q", i, l.To)
+		}
+
+		source := descriptor[to.ID].(map[string]interface{})["source"].(map[string]interface{})
+		inPort, _ := source[l.ToPort].(map[string]interface{})
+		if inPort == nil {
+			inPort = map[string]interface{}{}
+			source[l.ToPort] = inPort
+		}
+		outPorts, _ := inPort[from.ID].([]string)
+		inPort[from.ID] = append(outPorts, l.FromPort)
+	}
+
+	return descriptor, nil
+}
+
+func portNames(rawPorts json.RawMessage) (map[string]bool, error) {
+	names := make(map[string]bool)
+	if len(rawPorts) == 0 {
+		return names, nil
+	}
+	var ports []componentPort
+	if err := json.Unmarshal(rawPorts, &ports); err != nil {
+		return nil, err
+	}
+	for _, p := range ports {
+		names[p.Name] = true
+	}
+	return names, nil
+}
+
+// appOfComponent returns vendor.service for a vendor.service.module.Component type
+func appOfComponent(componentType string) string {
+	parts := strings.SplitN(componentType, ".", 3)
+	if len(parts) < 3 {
+		return componentType
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// validateFlowDescriptor checks components and links against their manifests. Missing
+// accounts are warnings because they can be bound after the flow is created.
+func validateFlowDescriptor(ctx context.Context, client *Client, components []flowComponentSpec, links []flowLinkSpec) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	componentTypes := make([]string, 0, len(components))
+	for _, c := range components {
+		componentTypes = append(componentTypes, c.Type)
+	}
+	manifests, err := fetchComponentManifests(ctx, client, componentTypes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	byLabel := make(map[string]componentManifest, len(components))
+	for _, c := range components {
+		manifest, ok := manifests[c.Type]
+		if !ok {
+			diags = append(diags, diag.Errorf("Component %q: unknown component type %s", c.Label, c.Type)...)
+			continue
+		}
+		byLabel[c.Label] = manifest
+
+		var props componentProperties
+		if len(manifest.Properties) > 0 {
+			if err := json.Unmarshal(manifest.Properties, &props); err != nil {
+				return diag.FromErr(fmt.Errorf("failed to parse properties of component manifest %s: %w", c.Type, err))
+			}
+		}
+		var missing []string
+		for _, field := range requiredFields(props.Schema) {
+			if _, ok := c.Properties[field]; !ok {
+				missing = append(missing, field)
+			}
+		}
+		sort.Strings(missing)
+		if len(missing) > 0 {
+			diags = append(diags, diag.Errorf("Component %q: missing required properties %s", c.Label, strings.Join(missing, ", "))...)
+		}
+
+		if len(manifest.Auth) > 0 && c.AccountID == "" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Component %q has no account", c.Label),
+				Detail:   fmt.Sprintf("%s requires an account of service %v. Set account_id or bind an account before starting the flow.", c.Type, manifest.Auth["service"]),
+			})
+		}
+	}
+
+	for i, l := range links {
+		if from, ok := byLabel[l.From]; ok {
+			outPorts, err := portNames(from.OutPorts)
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("failed to parse out ports of component manifest %s: %w", from.Name, err))
+			}
+			if !outPorts[l.FromPort] {
+				diags = append(diags, diag.Errorf("Link[%d]: component %q has no output port %q", i, l.From, l.FromPort)...)
+			}
+		}
+		if to, ok := byLabel[l.To]; ok {
+			inPorts, err := portNames(to.InPorts)
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("failed to parse in ports of component manifest %s: %w", to.Name, err))
+			}
+			if !inPorts[l.ToPort] {
+				diags = append(diags, diag.Errorf("Link[%d]: component %q has no input port %q", i, l.To, l.ToPort)...)
+			}
+		}
+	}
+
+	return diags
+}
+
+func dataSourceFlowDescriptorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	var diags diag.Diagnostics
+
+	components, err := expandFlowComponents(d.Get("component"))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	links := expandFlowLinks(d.Get("link"))
+
+	tflog.Debug(ctx, "Rendering Appmixer flow descriptor", map[string]interface{}{
+		"components": len(components),
+		"links":      len(links),
+	})
+
+	descriptor, err := renderFlowDescriptor(components, links)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("validate_manifests").(bool) {
+		diags = append(diags, validateFlowDescriptor(ctx, client, components, links)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	rendered, err := json.Marshal(descriptor)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to encode flow descriptor: %w", err))
+	}
+
+	componentIDs := make(map[string]string, len(components))
+	flatComponents := d.Get("component").([]interface{})
+	for i, c := range components {
+		componentIDs[c.Label] = c.ID
+		flatComponents[i].(map[string]interface{})["id"] = c.ID
+	}
+
+	sum := sha1.Sum(rendered)
+	d.SetId(fmt.Sprintf("%x", sum))
+	d.Set("json", string(rendered))
+	d.Set("component_ids", componentIDs)
+	if err := d.Set("component", flatComponents); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
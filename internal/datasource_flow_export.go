@@ -0,0 +1,344 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	flowBundleFormat  = "appmixer-flow-bundle"
+	flowBundleVersion = 1
+)
+
+// Key segments naming a secret value, e.g. client_secret, Authorization or refreshToken
+var flowBundleSecretSegments = map[string]bool{
+	"secret": true, "secrets": true, "password": true, "passwords": true, "passwd": true,
+	"passphrase": true, "token": true, "tokens": true, "credential": true, "credentials": true,
+	"authorization": true, "bearer": true, "cookie": true, "cookies": true, "apikey": true,
+}
+
+// Pairs of adjacent key segments naming a secret value, e.g. x-api-key or private_key
+var flowBundleSecretPairs = map[string]bool{
+	"api key": true, "private key": true, "signing key": true, "access key": true,
+	"session id": true, "session key": true,
+}
+
+// Last key segments of settings that describe a secret rather than hold it, e.g.
+// tokenUrl or passwordLength
+var flowBundleSecretSettings = map[string]bool{
+	"type": true, "url": true, "uri": true, "endpoint": true, "name": true, "label": true,
+	"field": true, "header": true, "expires": true, "expiry": true, "expiration": true,
+	"ttl": true, "timeout": true, "length": true, "required": true, "enabled": true,
+	"placeholder": true, "format": true, "path": true,
+}
+
+// keySegments splits a key into lower case words at separators and camel case
+// boundaries, e.g. X-API-Key into x, api, key and oauthScopes into oauth, scopes
+func keySegments(key string) []string {
+	var segments []string
+	var current []rune
+	runes := []rune(key)
+	flush := func() {
+		if len(current) > 0 {
+			segments = append(segments, strings.ToLower(string(current)))
+			current = nil
+		}
+	}
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case i > 0 && unicode.IsUpper(r):
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		case i > 0 && unicode.IsDigit(r) != unicode.IsDigit(runes[i-1]):
+			flush()
+		}
+		current = append(current, r)
+	}
+	flush()
+	return segments
+}
+
+// isSecretKey reports whether a key names a secret value. Whole words are matched, so
+// author, authType, oauthScopes or sessionTimeout are kept. auth and session only match
+// on their own or as the last word, e.g. an auth block or basicAuth.
+func isSecretKey(key string) bool {
+	segments := keySegments(key)
+	if len(segments) == 0 {
+		return false
+	}
+	last := segments[len(segments)-1]
+	if len(segments) > 1 && flowBundleSecretSettings[last] {
+		return false
+	}
+	if last == "auth" || (len(segments) == 1 && last == "session") {
+		return true
+	}
+	for i, segment := range segments {
+		if flowBundleSecretSegments[segment] {
+			return true
+		}
+		if i > 0 && flowBundleSecretPairs[segments[i-1]+" "+segment] {
+			return true
+		}
+	}
+	return false
+}
+
+// flowBundle is the portable representation of a flow without secret-looking values. References to
+// tenant specific objects are listed so they can be remapped on import.
+type flowBundle struct {
+	Format       string                 `json:"format"`
+	Version      int                    `json:"version"`
+	Name         string                 `json:"name"`
+	Descriptor   map[string]interface{} `json:"descriptor"`
+	CustomFields map[string]interface{} `json:"customFields,omitempty"`
+	References   flowBundleReferences   `json:"references"`
+	Redacted     []string               `json:"redacted"`
+}
+
+type flowBundleReferences struct {
+	Accounts []string `json:"accounts"`
+	Stores   []string `json:"stores"`
+	Users    []string `json:"users"`
+}
+
+// flowReferenceVisitor is called for every tenant specific reference in a descriptor.
+// It returns the replacement value.
+type flowReferenceVisitor func(kind, path, value string) string
+
+// walkFlowReferences visits account, data store and user references of a descriptor in
+// component ID order: accountId and accounts of components and the storeId and userId
+// config properties.
+func walkFlowReferences(descriptor map[string]interface{}, visit flowReferenceVisitor) {
+	componentIDs := make([]string, 0, len(descriptor))
+	for id := range descriptor {
+		componentIDs = append(componentIDs, id)
+	}
+	sort.Strings(componentIDs)
+
+	for _, id := range componentIDs {
+		component, ok := descriptor[id].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if accountID, ok := component["accountId"].(string); ok && accountID != "" {
+			component["accountId"] = visit("account", id+".accountId", accountID)
+		}
+		if accounts, ok := component["accounts"].(map[string]interface{}); ok {
+			for key, v := range accounts {
+				if accountID, ok := v.(string); ok && accountID != "" {
+					accounts[key] = visit("account", id+".accounts."+key, accountID)
+				}
+			}
+		}
+
+		config, _ := component["config"].(map[string]interface{})
+		properties, _ := config["properties"].(map[string]interface{})
+		if storeID, ok := properties["storeId"].(string); ok && storeID != "" {
+			properties["storeId"] = visit("store", id+".config.properties.storeId", storeID)
+		}
+		if userID, ok := properties["userId"].(string); ok && userID != "" {
+			properties["userId"] = visit("user", id+".config.properties.userId", userID)
+		}
+	}
+}
+
+// String values carrying credentials regardless of their key, e.g. header values
+var flowBundleSecretValuePattern = regexp.MustCompile(`(?i)^(bearer|basic|token)\s+\S+`)
+
+// redactSecrets removes secret-looking keys from value at any nesting level and returns
+// the paths of the removed keys, prefixed with path
+func redactSecrets(value interface{}, path string) []string {
+	var redacted []string
+	switch v := value.(type) {
+	case map[string]interface{}:
+		// Name/value pairs, e.g. headers given as [{"name": "Authorization", "value": "..."}]
+		for _, nameKey := range []string{"name", "key"} {
+			if name, ok := v[nameKey].(string); ok && isSecretKey(name) {
+				if _, ok := v["value"]; ok {
+					delete(v, "value")
+					redacted = append(redacted, path+".value")
+				}
+			}
+		}
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if isSecretKey(key) {
+				delete(v, key)
+				redacted = append(redacted, childPath)
+				continue
+			}
+			if text, ok := child.(string); ok && flowBundleSecretValuePattern.MatchString(text) {
+				delete(v, key)
+				redacted = append(redacted, childPath)
+				continue
+			}
+			redacted = append(redacted, redactSecrets(child, childPath)...)
+		}
+	case []interface{}:
+		for i, child := range v {
+			redacted = append(redacted, redactSecrets(child, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return redacted
+}
+
+// redactFlowSecrets removes secret-looking values from the config of every component
+// and from the custom fields, and returns their sorted paths
+func redactFlowSecrets(descriptor map[string]interface{}, customFields map[string]interface{}) []string {
+	redacted := []string{}
+	for id, raw := range descriptor {
+		component, _ := raw.(map[string]interface{})
+		if config, ok := component["config"]; ok {
+			redacted = append(redacted, redactSecrets(config, id+".config")...)
+		}
+	}
+	redacted = append(redacted, redactSecrets(customFields, "customFields")...)
+	sort.Strings(redacted)
+	return redacted
+}
+
+func uniqueSorted(values map[string]bool) []string {
+	list := make([]string, 0, len(values))
+	for v := range values {
+		list = append(list, v)
+	}
+	sort.Strings(list)
+	return list
+}
+
+func dataSourceFlowExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFlowExportRead,
+		Schema: map[string]*schema.Schema{
+			"flow_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the flow to export.",
+			},
+			"owner_user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The Appmixer user ID owning the flow. Requires admin permissions. Defaults to the authenticated user.",
+			},
+			// Computed fields
+			"bundle": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The JSON encoded flow bundle, without secret-looking values.",
+			},
+			"account_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sorted account IDs referenced by the flow, to be remapped on import.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"store_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sorted data store IDs referenced by the flow, to be remapped on import.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"user_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sorted user IDs referenced by the flow, to be remapped on import.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"redacted": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Paths of config and custom field values removed from the bundle because they look like secrets.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceFlowExportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*Client).ForUser(ctx, d.Get("owner_user_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	flowID := d.Get("flow_id").(string)
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Exporting Appmixer flow", map[string]interface{}{
+		"flow_id": flowID,
+	})
+
+	flow, err := fetchFlow(ctx, client, flowID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var descriptor map[string]interface{}
+	if err := json.Unmarshal(flow.Flow, &descriptor); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to parse descriptor of flow %s: %w", flowID, err))
+	}
+
+	redacted := redactFlowSecrets(descriptor, flow.CustomFields)
+	refs := map[string]map[string]bool{"account": {}, "store": {}, "user": {}}
+	walkFlowReferences(descriptor, func(kind, path, value string) string {
+		refs[kind][value] = true
+		return value
+	})
+
+	bundle := flowBundle{
+		Format:       flowBundleFormat,
+		Version:      flowBundleVersion,
+		Name:         flow.Name,
+		Descriptor:   descriptor,
+		CustomFields: flow.CustomFields,
+		References: flowBundleReferences{
+			Accounts: uniqueSorted(refs["account"]),
+			Stores:   uniqueSorted(refs["store"]),
+			Users:    uniqueSorted(refs["user"]),
+		},
+		Redacted: redacted,
+	}
+
+	encoded, err := json.Marshal(bundle)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to encode bundle of flow %s: %w", flowID, err))
+	}
+
+	if len(redacted) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Removed %d secret-looking values from the bundle of flow %s", len(redacted), flowID),
+			Detail:   "Set them again after importing the bundle. See the redacted attribute for their paths.",
+		})
+	}
+
+	sum := sha256.Sum256(encoded)
+	d.SetId(hex.EncodeToString(sum[:]))
+	d.Set("bundle", string(encoded))
+	d.Set("account_ids", bundle.References.Accounts)
+	d.Set("store_ids", bundle.References.Stores)
+	d.Set("user_ids", bundle.References.Users)
+	d.Set("redacted", redacted)
+
+	return diags
+}
@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestIsSecretKey(t *testing.T) {
+	tests := []struct {
+		key    string
+		secret bool
+	}{
+		// Redacted
+		{"password", true},
+		{"passwd", true},
+		{"client_secret", true},
+		{"clientSecret", true},
+		{"apiKey", true},
+		{"apikey", true},
+		{"api_key", true},
+		{"X-API-Key", true},
+		{"Authorization", true},
+		{"authToken", true},
+		{"access_token", true},
+		{"refreshToken", true},
+		{"bearer", true},
+		{"private_key", true},
+		{"privateKey", true},
+		{"signingKey", true},
+		{"awsAccessKey", true},
+		{"passphrase", true},
+		{"credentials", true},
+		{"cookie", true},
+		{"auth", true},
+		{"basicAuth", true},
+		{"session", true},
+		{"sessionId", true},
+
+		// Kept
+		{"author", false},
+		{"authType", false},
+		{"authorName", false},
+		{"oauthScopes", false},
+		{"sessionTimeout", false},
+		{"tokenUrl", false},
+		{"tokenType", false},
+		{"passwordLength", false},
+		{"keyword", false},
+		{"monkey", false},
+		{"url", false},
+		{"storeId", false},
+		{"channelId", false},
+		{"headers", false},
+		{"primaryKey", false},
+	}
+
+	for _, tt := range tests {
+		if got := isSecretKey(tt.key); got != tt.secret {
+			t.Errorf("isSecretKey(%q) = %v, want %v", tt.key, got, tt.secret)
+		}
+	}
+}
+
+func TestRedactFlowSecrets(t *testing.T) {
+	var descriptor map[string]interface{}
+	if err := json.Unmarshal([]byte(`{
+		"c1": {
+			"type": "appmixer.utils.http.Request",
+			"config": {
+				"properties": {
+					"url": "https://example.com",
+					"author": "ops",
+					"authType": "oauth2",
+					"headers": [
+						{"name": "Authorization", "value": "Bearer abc"},
+						{"name": "Accept", "value": "application/json"}
+					],
+					"auth": {"clientSecret": "s"},
+					"nested": {"private_key": "k", "callback": "Token xyz"}
+				}
+			}
+		}
+	}`), &descriptor); err != nil {
+		t.Fatal(err)
+	}
+	customFields := map[string]interface{}{"customer": "acme", "apiKey": "x"}
+
+	redacted := redactFlowSecrets(descriptor, customFields)
+
+	want := []string{
+		"c1.config.properties.auth",
+		"c1.config.properties.headers[0].value",
+		"c1.config.properties.nested.callback",
+		"c1.config.properties.nested.private_key",
+		"customFields.apiKey",
+	}
+	if !reflect.DeepEqual(redacted, want) {
+		t.Errorf("redacted %v, want %v", redacted, want)
+	}
+
+	properties := descriptor["c1"].(map[string]interface{})["config"].(map[string]interface{})["properties"].(map[string]interface{})
+	for _, key := range []string{"url", "author", "authType", "headers", "nested"} {
+		if _, ok := properties[key]; !ok {
+			t.Errorf("property %s was removed", key)
+		}
+	}
+	if _, ok := customFields["customer"]; !ok {
+		t.Error("custom field customer was removed")
+	}
+}
@@ -0,0 +1,289 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var flowLogSeverities = []string{"debug", "info", "warning", "error"}
+
+// Represents the response from GET /logs. Buckets aggregate all matching logs by port type,
+// hits are the requested page of logs.
+type flowLogsResponse struct {
+	Buckets []flowLogsBucket `json:"buckets"`
+	Hits    []flowLogEntry   `json:"hits"`
+}
+
+type flowLogsBucket struct {
+	Key      string `json:"key"`
+	DocCount int    `json:"doc_count"`
+}
+
+type flowLogEntry struct {
+	ID            string          `json:"_id"`
+	Timestamp     string          `json:"@timestamp"`
+	Severity      string          `json:"severity"`
+	FlowID        string          `json:"flowId"`
+	ComponentID   string          `json:"componentId"`
+	ComponentType string          `json:"componentType"`
+	PortType      string          `json:"portType"`
+	Port          string          `json:"port"`
+	Msg           json.RawMessage `json:"msg,omitempty"`
+}
+
+func dataSourceFlowLogs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFlowLogsRead,
+		Schema: map[string]*schema.Schema{
+			"flow_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the flow to read logs of.",
+			},
+			"owner_user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The Appmixer user ID owning the flow. Requires admin permissions. Defaults to the authenticated user.",
+			},
+			"component_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return logs of this component.",
+			},
+			"severity": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(flowLogSeverities, false),
+				Description:  "Only return logs with this severity: debug, info, warning or error.",
+			},
+			"since": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "Only return logs at or after this RFC 3339 timestamp.",
+			},
+			"until": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "Only return logs at or before this RFC 3339 timestamp.",
+			},
+			"sort": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "@timestamp:desc",
+				Description: "Sort logs (e.g., '@timestamp:asc').",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntBetween(0, 10000),
+				Description:  "Limit the number of logs returned. 0 only computes the counts.",
+			},
+			"offset": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Offset for pagination",
+			},
+			// Computed fields
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of logs matching the filters, independent of pagination.",
+			},
+			"messages_in": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of matching logs of messages received on input ports.",
+			},
+			"messages_out": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of matching logs of messages sent from output ports.",
+			},
+			"errors": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of logs with the error severity in the time range, independent of the severity filter.",
+			},
+			"counts": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Number of matching logs by port type.",
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"logs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"timestamp": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"severity": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"component_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"component_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"port_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"port": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The JSON encoded message or error of the log.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// flowLogsQuery builds the query string search of GET /logs from the severity and time range filters
+func flowLogsQuery(severity, since, until string) string {
+	var clauses []string
+	if severity != "" {
+		clauses = append(clauses, "severity:"+severity)
+	}
+	if since != "" || until != "" {
+		from, to := "*", "*"
+		if since != "" {
+			from = `"` + since + `"`
+		}
+		if until != "" {
+			to = `"` + until + `"`
+		}
+		clauses = append(clauses, fmt.Sprintf("@timestamp:[%s TO %s]", from, to))
+	}
+	return strings.Join(clauses, " AND ")
+}
+
+func fetchFlowLogs(ctx context.Context, client *Client, flowID, componentID, query, sort string, limit, offset int) (*flowLogsResponse, error) {
+	queryParams := url.Values{}
+	queryParams.Add("flowId", flowID)
+	queryParams.Add("from", fmt.Sprintf("%d", offset))
+	queryParams.Add("size", fmt.Sprintf("%d", limit))
+	if componentID != "" {
+		queryParams.Add("componentId", componentID)
+	}
+	if query != "" {
+		queryParams.Add("query", query)
+	}
+	if sort != "" {
+		queryParams.Add("sort", sort)
+	}
+
+	respBytes, err := client.DoRequest(ctx, "GET", "/logs?"+queryParams.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logs of flow %s: %w", flowID, err)
+	}
+
+	var logs flowLogsResponse
+	if err := json.Unmarshal(respBytes, &logs); err != nil {
+		return nil, fmt.Errorf("failed to parse logs of flow %s: %w", flowID, err)
+	}
+	return &logs, nil
+}
+
+func sumFlowLogBuckets(buckets []flowLogsBucket) int {
+	total := 0
+	for _, bucket := range buckets {
+		total += bucket.DocCount
+	}
+	return total
+}
+
+func dataSourceFlowLogsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*Client).ForUser(ctx, d.Get("owner_user_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	flowID := d.Get("flow_id").(string)
+	componentID := d.Get("component_id").(string)
+	severity := d.Get("severity").(string)
+	since := d.Get("since").(string)
+	until := d.Get("until").(string)
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Reading Appmixer flow logs data source", map[string]interface{}{
+		"flow_id":      flowID,
+		"component_id": componentID,
+		"severity":     severity,
+	})
+
+	logs, err := fetchFlowLogs(ctx, client, flowID, componentID, flowLogsQuery(severity, since, until), d.Get("sort").(string), d.Get("limit").(int), d.Get("offset").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	counts := make(map[string]int, len(logs.Buckets))
+	for _, bucket := range logs.Buckets {
+		counts[bucket.Key] = bucket.DocCount
+	}
+
+	// Buckets are by port type, errors need their own aggregation unless already filtered.
+	// The error count ignores the severity filter, so it is right whatever severity is read.
+	errorCount := sumFlowLogBuckets(logs.Buckets)
+	if severity != "error" {
+		errorLogs, err := fetchFlowLogs(ctx, client, flowID, componentID, flowLogsQuery("error", since, until), "", 0, 0)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		errorCount = sumFlowLogBuckets(errorLogs.Buckets)
+	}
+
+	logList := make([]map[string]interface{}, 0, len(logs.Hits))
+	for _, entry := range logs.Hits {
+		logList = append(logList, map[string]interface{}{
+			"id":             entry.ID,
+			"timestamp":      entry.Timestamp,
+			"severity":       entry.Severity,
+			"component_id":   entry.ComponentID,
+			"component_type": entry.ComponentType,
+			"port_type":      entry.PortType,
+			"port":           entry.Port,
+			"message":        string(entry.Msg),
+		})
+	}
+
+	d.SetId(fmt.Sprintf("flow-%s-logs-%d", flowID, len(logList)))
+	d.Set("total", sumFlowLogBuckets(logs.Buckets))
+	d.Set("messages_in", counts["in"])
+	d.Set("messages_out", counts["out"])
+	d.Set("errors", errorCount)
+	d.Set("counts", counts)
+	if err := d.Set("logs", logList); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,236 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Represents the variables an upstream component sends to an input port, from the
+// GET /variables/:flowId/:componentId API keyed by input port
+type flowVariableSource struct {
+	ComponentID string         `json:"componentId"`
+	Label       string         `json:"label,omitempty"`
+	Port        string         `json:"port"`
+	Variables   []flowVariable `json:"variables"`
+}
+
+// Represents a node of the variable tree. Value is the variable placeholder, e.g.
+// $.<componentId>.out.customer.email
+type flowVariable struct {
+	Name      string         `json:"name,omitempty"`
+	Label     string         `json:"label"`
+	Value     string         `json:"value,omitempty"`
+	Type      string         `json:"type,omitempty"`
+	Variables []flowVariable `json:"variables,omitempty"`
+}
+
+// flattenFlowVariables walks the variable tree depth first. Paths are relative to the
+// output port of the source component.
+func flattenFlowVariables(source flowVariableSource, inPort, parentPath string, variables []flowVariable, result []interface{}) []interface{} {
+	prefix := fmt.Sprintf("$.%s.%s.", source.ComponentID, source.Port)
+	for _, variable := range variables {
+		var path string
+		switch {
+		case strings.HasPrefix(variable.Value, prefix):
+			path = strings.TrimPrefix(variable.Value, prefix)
+		case variable.Name != "" && parentPath != "":
+			path = parentPath + "." + variable.Name
+		case variable.Name != "":
+			path = variable.Name
+		default:
+			path = parentPath
+		}
+		placeholder := variable.Value
+		if placeholder == "" && path != "" {
+			placeholder = prefix + path
+		}
+
+		result = append(result, map[string]interface{}{
+			"in_port":             inPort,
+			"source_component_id": source.ComponentID,
+			"source_port":         source.Port,
+			"label":               variable.Label,
+			"path":                path,
+			"type":                variable.Type,
+			"placeholder":         placeholder,
+			"reference":           "{{{" + placeholder + "}}}",
+			"leaf":                len(variable.Variables) == 0,
+		})
+		result = flattenFlowVariables(source, inPort, path, variable.Variables, result)
+	}
+	return result
+}
+
+func dataSourceFlowVariables() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFlowVariablesRead,
+		Schema: map[string]*schema.Schema{
+			"flow_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the flow.",
+			},
+			"component_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the component whose available variables to read.",
+			},
+			"port": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return variables available on this input port of the component.",
+			},
+			"owner_user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The Appmixer user ID owning the flow. Requires admin permissions. Defaults to the authenticated user.",
+			},
+			// Computed fields
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The JSON encoded variable tree as returned by Appmixer, keyed by input port.",
+			},
+			"references": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Variable references ready to use in component properties, keyed by <source_component_id>.<source_port>.<path>.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"variables": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"in_port": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The input port of the component the variable is available on.",
+						},
+						"source_component_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source_port": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"label": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The path of the variable in messages of the source port.",
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"placeholder": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"reference": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The placeholder wrapped in braces, as used in component properties.",
+						},
+						"leaf": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the variable has no nested variables.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFlowVariablesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*Client).ForUser(ctx, d.Get("owner_user_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	flowID := d.Get("flow_id").(string)
+	componentID := d.Get("component_id").(string)
+	port := d.Get("port").(string)
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Reading Appmixer flow variables data source", map[string]interface{}{
+		"flow_id":      flowID,
+		"component_id": componentID,
+		"port":         port,
+	})
+
+	respBytes, err := client.DoRequest(ctx, "GET", fmt.Sprintf("/variables/%s/%s", url.PathEscape(flowID), url.PathEscape(componentID)), nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to read variables of component %s in flow %s: %w", componentID, flowID, err))
+	}
+
+	var sourcesByPort map[string][]flowVariableSource
+	if err := json.Unmarshal(respBytes, &sourcesByPort); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to parse variables of component %s in flow %s: %w", componentID, flowID, err))
+	}
+
+	if port != "" {
+		if _, ok := sourcesByPort[port]; !ok {
+			return diag.Errorf("Component %s in flow %s has no variables on input port %q", componentID, flowID, port)
+		}
+		sourcesByPort = map[string][]flowVariableSource{port: sourcesByPort[port]}
+	}
+
+	inPorts := make([]string, 0, len(sourcesByPort))
+	for inPort := range sourcesByPort {
+		inPorts = append(inPorts, inPort)
+	}
+	sort.Strings(inPorts)
+
+	variableList := make([]interface{}, 0)
+	for _, inPort := range inPorts {
+		for _, source := range sourcesByPort[inPort] {
+			variableList = flattenFlowVariables(source, inPort, "", source.Variables, variableList)
+		}
+	}
+
+	references := make(map[string]string, len(variableList))
+	for _, raw := range variableList {
+		variable := raw.(map[string]interface{})
+		if variable["path"].(string) == "" {
+			continue
+		}
+		key := fmt.Sprintf("%s.%s.%s", variable["source_component_id"], variable["source_port"], variable["path"])
+		references[key] = variable["reference"].(string)
+	}
+
+	// Keep fields this provider does not model in the raw tree
+	encoded := respBytes
+	if port != "" {
+		var rawByPort map[string]json.RawMessage
+		if err := json.Unmarshal(respBytes, &rawByPort); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to parse variables of component %s in flow %s: %w", componentID, flowID, err))
+		}
+		if encoded, err = json.Marshal(map[string]json.RawMessage{port: rawByPort[port]}); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to encode variables: %w", err))
+		}
+	}
+
+	d.SetId(fmt.Sprintf("flow-%s-component-%s-variables", flowID, componentID))
+	d.Set("json", string(encoded))
+	d.Set("references", references)
+	if err := d.Set("variables", variableList); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
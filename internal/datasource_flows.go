@@ -0,0 +1,229 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// listFlows pages through GET /flows. String custom fields are passed as filters to narrow
+// down the result, matching is done by matchFlowCustomFields.
+func listFlows(ctx context.Context, client *Client, pattern string, customFields map[string]interface{}) ([]flowResponse, error) {
+	pageSize := 100
+	offset := 0
+
+	var flows []flowResponse
+	for {
+		queryParams := url.Values{}
+		queryParams.Add("limit", fmt.Sprintf("%d", pageSize))
+		queryParams.Add("offset", fmt.Sprintf("%d", offset))
+		queryParams.Add("projection", "-flow,-thumbnail")
+		if pattern != "" {
+			queryParams.Add("pattern", pattern)
+		}
+		for key, value := range customFields {
+			if s, ok := value.(string); ok {
+				queryParams.Add("filter", fmt.Sprintf("customFields.%s:%s", key, s))
+			}
+		}
+
+		respBytes, err := client.DoRequest(ctx, "GET", "/flows?"+queryParams.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list flows: %w", err)
+		}
+
+		var page []flowResponse
+		if err := json.Unmarshal(respBytes, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse flows: %w", err)
+		}
+		flows = append(flows, page...)
+
+		if len(page) < pageSize {
+			return flows, nil
+		}
+		offset += pageSize
+	}
+}
+
+// matchFlowCustomFields reports whether the flow has all the wanted custom fields with
+// deeply equal values
+func matchFlowCustomFields(flow flowResponse, wanted map[string]interface{}) bool {
+	for key, want := range wanted {
+		value, ok := flow.CustomFields[key]
+		if !ok {
+			return false
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return false
+		}
+		wantEncoded, err := json.Marshal(want)
+		if err != nil || !jsonEquivalent(string(encoded), string(wantEncoded)) {
+			return false
+		}
+	}
+	return true
+}
+
+func dataSourceFlows() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFlowsRead,
+		Schema: map[string]*schema.Schema{
+			"owner_user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The Appmixer user ID whose flows to list. Requires admin permissions. Defaults to the authenticated user.",
+			},
+			"pattern": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return flows whose name matches the pattern.",
+			},
+			"stage": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"running", "stopped"}, false),
+				Description:  "Only return flows in this stage: running or stopped.",
+			},
+			"custom_fields": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Only return flows having all these custom fields. Values are JSON encoded and compared by deep equality.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsJSON,
+				},
+			},
+			// Computed fields
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the returned flows.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"flows": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"flow_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"stage": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"custom_fields": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "JSON encoded custom fields of the flow.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"btime": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"mtime": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFlowsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*Client).ForUser(ctx, d.Get("owner_user_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	stage := d.Get("stage").(string)
+	var diags diag.Diagnostics
+
+	wanted := make(map[string]interface{})
+	for key, value := range d.Get("custom_fields").(map[string]interface{}) {
+		decoded, err := decodeJSONAttribute("custom field "+key, value.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		wanted[key] = decoded
+	}
+
+	tflog.Debug(ctx, "Reading Appmixer flows data source", map[string]interface{}{
+		"pattern":       d.Get("pattern").(string),
+		"stage":         stage,
+		"custom_fields": len(wanted),
+	})
+
+	flows, err := listFlows(ctx, client, d.Get("pattern").(string), wanted)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sort.SliceStable(flows, func(i, j int) bool {
+		return flows[i].FlowID < flows[j].FlowID
+	})
+
+	ids := make([]string, 0, len(flows))
+	flowList := make([]map[string]interface{}, 0, len(flows))
+	for _, flow := range flows {
+		if stage != "" && flow.Stage != stage {
+			continue
+		}
+		if !matchFlowCustomFields(flow, wanted) {
+			continue
+		}
+
+		// Unlike the resources, list every custom field including the reserved ones
+		customFields := make(map[string]string, len(flow.CustomFields))
+		for key, value := range flow.CustomFields {
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("failed to encode custom field %s of flow %s: %w", key, flow.FlowID, err))
+			}
+			customFields[key] = string(encoded)
+		}
+		description, _ := flow.CustomFields["description"].(string)
+
+		ids = append(ids, flow.FlowID)
+		flowList = append(flowList, map[string]interface{}{
+			"flow_id":       flow.FlowID,
+			"name":          flow.Name,
+			"stage":         flow.Stage,
+			"user_id":       flow.UserID,
+			"description":   description,
+			"custom_fields": customFields,
+			"btime":         flow.Btime,
+			"mtime":         flow.Mtime,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("flows-%d", len(flowList)))
+	d.Set("ids", ids)
+	if err := d.Set("flows", flowList); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,198 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// userPlan is shared between the user resource, the user data sources and appmixer_plans.
+// Older Appmixer versions return the plan of a user as a plain string (the plan name),
+// newer ones as an object with the plan name and its limits. Limit values are kept as
+// JSON so numbers, booleans and nested values round-trip unchanged.
+type userPlan struct {
+	Name   string                     `json:"name"`
+	Limits map[string]json.RawMessage `json:"limits,omitempty"`
+}
+
+func (p *userPlan) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		p.Name = name
+		p.Limits = nil
+		return nil
+	}
+
+	// The alias has no UnmarshalJSON method, which would recurse
+	type plainUserPlan userPlan
+	var plan plainUserPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return fmt.Errorf("unexpected plan format: %w", err)
+	}
+
+	*p = userPlan(plan)
+	return nil
+}
+
+// flattenUserPlan converts a plan into the nested block representation used in the schemas
+func flattenUserPlan(p *userPlan) []interface{} {
+	if p == nil {
+		return []interface{}{}
+	}
+
+	limits := make(map[string]interface{}, len(p.Limits))
+	for k, v := range p.Limits {
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, v); err != nil {
+			limits[k] = string(v)
+			continue
+		}
+		limits[k] = compacted.String()
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"name":   p.Name,
+			"limits": limits,
+		},
+	}
+}
+
+// expandUserPlan converts the plan block into the API representation. Only limits present
+// in the configuration are sent, the computed limits of the current plan would otherwise
+// be sent as overrides when only the plan name changes.
+func expandUserPlan(d *schema.ResourceData) (*userPlan, error) {
+	planList, ok := d.Get("plan").([]interface{})
+	if !ok || len(planList) == 0 || planList[0] == nil {
+		return nil, nil
+	}
+
+	planMap := planList[0].(map[string]interface{})
+	plan := &userPlan{
+		Name: planMap["name"].(string),
+	}
+
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() {
+		return plan, nil
+	}
+	rawPlan := rawConfig.GetAttr("plan")
+	if rawPlan.IsNull() || !rawPlan.IsKnown() || rawPlan.LengthInt() == 0 {
+		return plan, nil
+	}
+	rawLimits := rawPlan.AsValueSlice()[0].GetAttr("limits")
+	if rawLimits.IsNull() || !rawLimits.IsKnown() {
+		return plan, nil
+	}
+
+	limits := planMap["limits"].(map[string]interface{})
+	plan.Limits = make(map[string]json.RawMessage)
+	for k := range rawLimits.AsValueMap() {
+		value, ok := limits[k].(string)
+		if !ok {
+			continue
+		}
+		if !json.Valid([]byte(value)) {
+			return nil, fmt.Errorf("limit %s of plan %s is not valid JSON: %s", k, plan.Name, value)
+		}
+		plan.Limits[k] = json.RawMessage(value)
+	}
+
+	return plan, nil
+}
+
+// computedUserPlanSchema is the read-only plan block used by the user data sources
+func computedUserPlanSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The plan assigned to the user.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The name of the plan.",
+				},
+				"limits": {
+					Type:        schema.TypeMap,
+					Computed:    true,
+					Description: "JSON encoded limits of the plan keyed by limit name.",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePlans() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePlansRead,
+		Schema: map[string]*schema.Schema{
+			"plans": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of plans configured in the tenant, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the plan.",
+						},
+						"limits": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "JSON encoded limits of the plan keyed by limit name.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePlansRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	var diags diag.Diagnostics
+
+	if !hasAdminPermissions(client) {
+		return diag.Errorf("Listing plans requires admin permissions")
+	}
+
+	tflog.Debug(ctx, "Reading Appmixer plans data source")
+
+	respBytes, err := client.DoRequest(ctx, "GET", "/plans", nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to list plans: %w", err))
+	}
+
+	var plansData []userPlan
+	if err := json.Unmarshal(respBytes, &plansData); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to parse plans response: %w", err))
+	}
+
+	sort.Slice(plansData, func(i, j int) bool {
+		return plansData[i].Name < plansData[j].Name
+	})
+
+	plans := make([]interface{}, 0, len(plansData))
+	for i := range plansData {
+		plans = append(plans, flattenUserPlan(&plansData[i])...)
+	}
+
+	if err := d.Set("plans", plans); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("plans-%d", len(plans)))
+
+	return diags
+}
@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSystemConfigs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSystemConfigsRead,
+		Schema: map[string]*schema.Schema{
+			"key_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return keys starting with this prefix.",
+			},
+			// Computed fields
+			"values": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "JSON encoded values keyed by configuration key.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"configs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The JSON encoded value.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the value: string, number, bool or json.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSystemConfigsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	prefix := d.Get("key_prefix").(string)
+	var diags diag.Diagnostics
+
+	if !hasAdminPermissions(client) {
+		return diag.Errorf("Reading system configuration requires admin permissions")
+	}
+
+	tflog.Debug(ctx, "Reading Appmixer system configuration data source", map[string]interface{}{
+		"key_prefix": prefix,
+	})
+
+	entries, err := fetchSystemConfig(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+
+	configs := make([]map[string]interface{}, 0, len(entries))
+	values := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Key, prefix) {
+			continue
+		}
+		configs = append(configs, map[string]interface{}{
+			"key":   entry.Key,
+			"value": string(entry.Value),
+			"type":  systemConfigType(entry.Value),
+		})
+		values[entry.Key] = string(entry.Value)
+	}
+
+	d.SetId(fmt.Sprintf("system-configs-%s-%d", prefix, len(configs)))
+	d.Set("values", values)
+	if err := d.Set("configs", configs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
@@ -0,0 +1,179 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Represents a message from the GET /unprocessed-messages API
+type unprocessedMessage struct {
+	MessageID   string          `json:"messageId"`
+	FlowID      string          `json:"flowId"`
+	ComponentID string          `json:"componentId"`
+	UserID      string          `json:"userId"`
+	Err         json.RawMessage `json:"err,omitempty"`
+	Created     string          `json:"created"`
+	Messages    json.RawMessage `json:"messages,omitempty"`
+}
+
+func fetchUnprocessedMessages(ctx context.Context, client *Client) ([]unprocessedMessage, error) {
+	respBytes, err := client.DoRequest(ctx, "GET", "/unprocessed-messages", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unprocessed messages: %w", err)
+	}
+
+	var messages []unprocessedMessage
+	if err := json.Unmarshal(respBytes, &messages); err != nil {
+		return nil, fmt.Errorf("failed to parse unprocessed messages: %w", err)
+	}
+	return messages, nil
+}
+
+// unprocessedMessageError returns the error of a message as text, Appmixer stores
+// either a string or a serialized error object
+func unprocessedMessageError(raw json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+	var obj struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(raw, &obj); err == nil && obj.Message != "" {
+		return obj.Message
+	}
+	return string(raw)
+}
+
+func dataSourceUnprocessedMessages() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceUnprocessedMessagesRead,
+		Schema: map[string]*schema.Schema{
+			"owner_user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The Appmixer user ID whose messages to list. Requires admin permissions. Defaults to the authenticated user.",
+			},
+			"flow_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return messages of this flow.",
+			},
+			"component_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return messages of this component.",
+			},
+			// Computed fields
+			"message_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the returned messages, oldest first.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"messages": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"message_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"flow_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"component_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"error": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The error the message failed with.",
+						},
+						"error_details": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The JSON encoded error as stored by Appmixer.",
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"content": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The JSON encoded input messages of the component.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUnprocessedMessagesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*Client).ForUser(ctx, d.Get("owner_user_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	flowID := d.Get("flow_id").(string)
+	componentID := d.Get("component_id").(string)
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Reading Appmixer unprocessed messages data source", map[string]interface{}{
+		"flow_id":      flowID,
+		"component_id": componentID,
+	})
+
+	messages, err := fetchUnprocessedMessages(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The API has no filters, so filter here
+	var filtered []unprocessedMessage
+	for _, msg := range messages {
+		if flowID != "" && msg.FlowID != flowID {
+			continue
+		}
+		if componentID != "" && msg.ComponentID != componentID {
+			continue
+		}
+		filtered = append(filtered, msg)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Created < filtered[j].Created
+	})
+
+	messageIDs := make([]string, 0, len(filtered))
+	messageList := make([]map[string]interface{}, 0, len(filtered))
+	for _, msg := range filtered {
+		messageIDs = append(messageIDs, msg.MessageID)
+		messageList = append(messageList, map[string]interface{}{
+			"message_id":    msg.MessageID,
+			"flow_id":       msg.FlowID,
+			"component_id":  msg.ComponentID,
+			"error":         unprocessedMessageError(msg.Err),
+			"error_details": string(msg.Err),
+			"created":       msg.Created,
+			"content":       string(msg.Messages),
+		})
+	}
+
+	d.SetId(fmt.Sprintf("unprocessed-messages-%s-%s-%s", d.Get("owner_user_id").(string), flowID, componentID))
+	d.Set("message_ids", messageIDs)
+	if err := d.Set("messages", messageList); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
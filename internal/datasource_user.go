@@ -10,13 +10,13 @@ import (
 
 // userResponse is shared between datasource_user.go and resource_user.go
 type userResponse struct {
-	ID       string          `json:"id"`
-	Username string          `json:"username"`
-	IsActive bool            `json:"isActive"`
-	Email    string          `json:"email"`
-	Plan     json.RawMessage `json:"plan"`
-	Scope    []string        `json:"scope"`
-	Created  string          `json:"created"`
+	ID       string    `json:"id"`
+	Username string    `json:"username"`
+	IsActive bool      `json:"isActive"`
+	Email    string    `json:"email"`
+	Plan     *userPlan `json:"plan"`
+	Scope    []string  `json:"scope"`
+	Created  string    `json:"created"`
 }
 
 func dataSourceUser() *schema.Resource {
@@ -39,13 +39,7 @@ func dataSourceUser() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
-			"plan": {
-				Type:     schema.TypeMap,
-				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-			},
+			"plan": computedUserPlanSchema(),
 			"scope": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -81,20 +75,7 @@ func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface
 	d.Set("email", userRes.Email)
 	d.Set("is_active", userRes.IsActive)
 
-	// Handle plan as a map
-	if userRes.Plan != nil {
-		var planMap map[string]interface{}
-		if err := json.Unmarshal(userRes.Plan, &planMap); err == nil {
-			d.Set("plan", planMap)
-		} else {
-			// If it's not an object, try string
-			var planStr string
-			if err := json.Unmarshal(userRes.Plan, &planStr); err == nil {
-				d.Set("plan", map[string]interface{}{"name": planStr})
-			}
-		}
-	}
-
+	d.Set("plan", flattenUserPlan(userRes.Plan))
 	d.Set("scope", userRes.Scope)
 	d.Set("created", userRes.Created)
 
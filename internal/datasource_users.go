@@ -62,13 +62,7 @@ func dataSourceUsers() *schema.Resource {
 							Type:     schema.TypeBool,
 							Computed: true,
 						},
-						"plan": {
-							Type:     schema.TypeMap,
-							Computed: true,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
-							},
-						},
+						"plan": computedUserPlanSchema(),
 						"scope": {
 							Type:     schema.TypeList,
 							Computed: true,
@@ -142,24 +136,11 @@ func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, m interfac
 			"username":  user.Username,
 			"email":     user.Email,
 			"is_active": user.IsActive,
+			"plan":      flattenUserPlan(user.Plan),
 			"scope":     user.Scope,
 			"created":   user.Created,
 		}
 
-		// Handle plan as a map
-		if user.Plan != nil {
-			var planMap map[string]interface{}
-			if err := json.Unmarshal(user.Plan, &planMap); err == nil {
-				userData["plan"] = planMap
-			} else {
-				// If it's not an object, try string
-				var planStr string
-				if err := json.Unmarshal(user.Plan, &planStr); err == nil {
-					userData["plan"] = map[string]interface{}{"name": planStr}
-				}
-			}
-		}
-
 		users[i] = userData
 	}
 
@@ -0,0 +1,291 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Represents a flow from the GET /flows/:id API
+type flowResponse struct {
+	FlowID       string                 `json:"flowId"`
+	UserID       string                 `json:"userId"`
+	Name         string                 `json:"name"`
+	Stage        string                 `json:"stage"`
+	Flow         json.RawMessage        `json:"flow"`
+	Wizard       json.RawMessage        `json:"wizard,omitempty"`
+	CustomFields map[string]interface{} `json:"customFields"`
+	Thumbnail    string                 `json:"thumbnail,omitempty"`
+	SharedWith   []flowShareEntry       `json:"sharedWith"`
+	Btime        string                 `json:"btime"`
+	Mtime        string                 `json:"mtime"`
+}
+
+// Custom fields the provider sets itself, they cannot be managed through custom_fields
+var reservedFlowCustomFields = map[string]bool{
+	"template":    true,
+	"templateId":  true,
+	"description": true,
+}
+
+// Represents an entry of the sharedWith list of a flow. Exactly one of UserID, Email,
+// Scope, Domain or Vendor identifies who the flow is shared with.
+type flowShareEntry struct {
+	UserID      string   `json:"userId,omitempty"`
+	Email       string   `json:"email,omitempty"`
+	Scope       string   `json:"scope,omitempty"`
+	Domain      string   `json:"domain,omitempty"`
+	Vendor      string   `json:"vendor,omitempty"`
+	Permissions []string `json:"permissions"`
+}
+
+// Represents the response from POST /flows
+type createFlowResponse struct {
+	FlowID string `json:"flowId"`
+}
+
+func fetchFlow(ctx context.Context, client *Client, flowID string) (*flowResponse, error) {
+	respBytes, err := client.DoRequest(ctx, "GET", fmt.Sprintf("/flows/%s", flowID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read flow %s: %w", flowID, err)
+	}
+
+	var flow flowResponse
+	if err := json.Unmarshal(respBytes, &flow); err != nil {
+		return nil, fmt.Errorf("failed to parse flow %s: %w", flowID, err)
+	}
+	return &flow, nil
+}
+
+func createFlow(ctx context.Context, client *Client, body map[string]interface{}) (string, error) {
+	respBytes, err := client.DoRequest(ctx, "POST", "/flows", body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create flow %v: %w", body["name"], err)
+	}
+
+	var createRes createFlowResponse
+	if err := json.Unmarshal(respBytes, &createRes); err != nil {
+		return "", fmt.Errorf("failed to parse create flow response: %w", err)
+	}
+	if createRes.FlowID == "" {
+		return "", fmt.Errorf("API did not return a flowId after creating flow %v", body["name"])
+	}
+	return createRes.FlowID, nil
+}
+
+func updateFlow(ctx context.Context, client *Client, flowID string, body map[string]interface{}) error {
+	if _, err := client.DoRequest(ctx, "PUT", fmt.Sprintf("/flows/%s", flowID), body); err != nil {
+		return fmt.Errorf("failed to update flow %s: %w", flowID, err)
+	}
+	return nil
+}
+
+// stopAndDeleteFlow stops a running flow first, Appmixer refuses to delete running flows
+func stopAndDeleteFlow(ctx context.Context, client *Client, flowID, stage string) error {
+	if stage == "running" {
+		if _, err := client.DoRequest(ctx, "PATCH", fmt.Sprintf("/flows/%s/coordinator", flowID), map[string]string{"command": "stop"}); err != nil {
+			return fmt.Errorf("failed to stop flow %s: %w", flowID, err)
+		}
+	}
+	if _, err := client.DoRequest(ctx, "DELETE", fmt.Sprintf("/flows/%s", flowID), nil); err != nil {
+		return fmt.Errorf("failed to delete flow %s: %w", flowID, err)
+	}
+	return nil
+}
+
+// waitForFlowStage polls the flow until it reaches the stage, e.g. until a started flow is running
+func waitForFlowStage(ctx context.Context, client *Client, flowID, stage string, timeout time.Duration) error {
+	retryDelay := 2 * time.Second
+	deadline := time.Now().Add(timeout)
+
+	for {
+		flow, err := fetchFlow(ctx, client, flowID)
+		if err != nil {
+			return err
+		}
+		if flow.Stage == stage {
+			return nil
+		}
+
+		if time.Now().Add(retryDelay).After(deadline) {
+			return fmt.Errorf("flow %s did not reach stage %s within %s, last stage was %s", flowID, stage, timeout, flow.Stage)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+// decodeJSONAttribute decodes a JSON string attribute into a value to embed in a request body
+func decodeJSONAttribute(name, value string) (interface{}, error) {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return decoded, nil
+}
+
+// preserveJSONFormatting returns prior when it is equivalent to remote, so the
+// configured formatting stays in state
+func preserveJSONFormatting(prior, remote string) string {
+	if prior != "" && jsonEquivalent(prior, remote) {
+		return prior
+	}
+	return remote
+}
+
+// fetchComponentManifests returns the manifests of the given component types by type.
+// Manifests of all components of each app are fetched once, unknown apps are skipped.
+func fetchComponentManifests(ctx context.Context, client *Client, componentTypes []string) (map[string]componentManifest, error) {
+	manifests := make(map[string]componentManifest)
+	fetchedApps := make(map[string]bool)
+	for _, componentType := range componentTypes {
+		appID := appOfComponent(componentType)
+		if fetchedApps[appID] {
+			continue
+		}
+		fetchedApps[appID] = true
+
+		appComponents, err := fetchAppComponents(ctx, client, appID)
+		if err != nil {
+			if strings.Contains(err.Error(), "status 404") {
+				continue
+			}
+			return nil, err
+		}
+		for _, manifest := range appComponents {
+			manifests[manifest.Name] = manifest
+		}
+	}
+	return manifests, nil
+}
+
+// Represents a webhook trigger component of a flow
+type flowWebhook struct {
+	ComponentID string
+	Label       string
+	Type        string
+	URL         string
+	Async       bool
+	Methods     []string
+}
+
+// fetchFlowWebhooks returns the webhook trigger components of a flow descriptor with their
+// public URLs, in component ID order. Components are webhooks when their manifest says so.
+func fetchFlowWebhooks(ctx context.Context, client *Client, flowID string, descriptor map[string]interface{}) ([]flowWebhook, error) {
+	componentIDs := make([]string, 0, len(descriptor))
+	componentTypes := make([]string, 0, len(descriptor))
+	for componentID, raw := range descriptor {
+		component, _ := raw.(map[string]interface{})
+		if componentType, ok := component["type"].(string); ok {
+			componentIDs = append(componentIDs, componentID)
+			componentTypes = append(componentTypes, componentType)
+		}
+	}
+	sort.Strings(componentIDs)
+
+	manifests, err := fetchComponentManifests(ctx, client, componentTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := []flowWebhook{}
+	for _, componentID := range componentIDs {
+		component := descriptor[componentID].(map[string]interface{})
+		componentType := component["type"].(string)
+		manifest, ok := manifests[componentType]
+		if !ok || !manifest.Webhook {
+			continue
+		}
+		label, _ := component["label"].(string)
+		// Methods that cannot be decoded are reported by the app components data source
+		methods, _ := decodeHTTPRequestMethods(manifest.HttpRequestMethods)
+		webhooks = append(webhooks, flowWebhook{
+			ComponentID: componentID,
+			Label:       label,
+			Type:        componentType,
+			URL:         fmt.Sprintf("%s/flows/%s/components/%s", strings.TrimRight(client.ApiURL, "/"), flowID, componentID),
+			Async:       manifest.WebhookAsync,
+			Methods:     methods,
+		})
+	}
+	return webhooks, nil
+}
+
+func flowCustomFieldsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeMap,
+		Optional:         true,
+		DiffSuppressFunc: suppressEquivalentJSON,
+		Description:      "Custom fields of the flow, e.g. to tag flows by customer or environment. Values are JSON encoded and compared by deep equality. Custom fields not set here are left as they are.",
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringIsJSON,
+		},
+	}
+}
+
+// expandFlowCustomFields decodes the custom_fields attribute and merges the fields the
+// provider sets itself, which must not be configured
+func expandFlowCustomFields(v interface{}, reserved map[string]interface{}) (map[string]interface{}, error) {
+	raw, _ := v.(map[string]interface{})
+	customFields := make(map[string]interface{}, len(raw)+len(reserved))
+	for key, value := range raw {
+		if reservedFlowCustomFields[key] {
+			return nil, fmt.Errorf("custom field %s is managed by the provider and cannot be set in custom_fields", key)
+		}
+		decoded, err := decodeJSONAttribute("custom field "+key, value.(string))
+		if err != nil {
+			return nil, err
+		}
+		customFields[key] = decoded
+	}
+	for key, value := range reserved {
+		customFields[key] = value
+	}
+	return customFields, nil
+}
+
+// mergeFlowCustomFields adds the remote custom fields Terraform does not manage to
+// customFields, so updates keep fields set in the UI or through the API. Fields in old,
+// the previously managed ones, are left out so removing them from the configuration
+// deletes them.
+func mergeFlowCustomFields(customFields map[string]interface{}, remote map[string]interface{}, old interface{}) {
+	managed, _ := old.(map[string]interface{})
+	for key, value := range remote {
+		if _, ok := managed[key]; ok {
+			continue
+		}
+		if _, ok := customFields[key]; !ok && !reservedFlowCustomFields[key] {
+			customFields[key] = value
+		}
+	}
+}
+
+// flattenFlowCustomFields encodes the custom fields in prior, the ones managed by
+// Terraform, keeping the configured formatting of values that did not change. Removed
+// fields are left out so the plan sets them again.
+func flattenFlowCustomFields(customFields map[string]interface{}, prior map[string]interface{}) (map[string]string, error) {
+	result := make(map[string]string, len(prior))
+	for key, value := range customFields {
+		if _, ok := prior[key]; !ok || reservedFlowCustomFields[key] {
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode custom field %s: %w", key, err)
+		}
+		priorValue, _ := prior[key].(string)
+		result[key] = preserveJSONFormatting(priorValue, string(encoded))
+	}
+	return result, nil
+}
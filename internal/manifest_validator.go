@@ -0,0 +1,399 @@
+package internal
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// manifestIssue is a single problem found in a component manifest, located by a
+// path into the manifest such as "inPorts[0].inspector.inputs.text.type"
+type manifestIssue struct {
+	Path    string
+	Message string
+	Warning bool
+}
+
+func (i manifestIssue) String() string {
+	if i.Path == "" {
+		return i.Message
+	}
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+var (
+	componentNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+(\.[a-zA-Z0-9_-]+){3}$`)
+	authServicePattern   = regexp.MustCompile(`^[a-zA-Z0-9_-]+(:[a-zA-Z0-9_-]+)+$`)
+
+	validHTTPMethods = map[string]bool{
+		"GET": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true, "HEAD": true, "OPTIONS": true,
+	}
+	validSchemaTypes = map[string]bool{
+		"string": true, "number": true, "integer": true, "boolean": true, "object": true, "array": true, "null": true,
+	}
+	knownInspectorTypes = map[string]bool{
+		"text": true, "textarea": true, "number": true, "select": true, "multiselect": true, "toggle": true,
+		"date-time": true, "expression": true, "filepicker": true, "googlepicker": true, "onedrivepicker": true,
+		"color-palette": true, "password": true, "code": true, "checkbox": true, "radio": true,
+	}
+)
+
+// manifestValidator accumulates issues while walking a manifest
+type manifestValidator struct {
+	issues []manifestIssue
+}
+
+func (v *manifestValidator) errorf(p, format string, args ...interface{}) {
+	v.issues = append(v.issues, manifestIssue{Path: p, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *manifestValidator) warnf(p, format string, args ...interface{}) {
+	v.issues = append(v.issues, manifestIssue{Path: p, Message: fmt.Sprintf(format, args...), Warning: true})
+}
+
+// validateComponentManifest parses a component.json offline and reports every problem it finds.
+// Auth services are checked against knownAuthServices only when that list is not empty.
+// The returned manifest is nil when the content could not be decoded at all.
+func validateComponentManifest(data []byte, knownAuthServices []string) (*componentManifest, []manifestIssue) {
+	v := &manifestValidator{}
+
+	var manifest componentManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxErr):
+			line, col := offsetToLineColumn(data, syntaxErr.Offset)
+			v.errorf("", "invalid JSON at line %d, column %d: %s", line, col, syntaxErr.Error())
+		case errors.As(err, &typeErr):
+			v.errorf(typeErr.Field, "expected %s, got JSON %s", typeErr.Type.String(), typeErr.Value)
+		default:
+			v.errorf("", "%s", err.Error())
+		}
+		return nil, v.issues
+	}
+
+	if manifest.Name == "" {
+		v.errorf("name", "is required")
+	} else if !componentNamePattern.MatchString(manifest.Name) {
+		v.errorf("name", "%q must have the form vendor.service.module.Component", manifest.Name)
+	}
+
+	if manifest.WebhookAsync && !manifest.Webhook {
+		v.errorf("webhookAsync", "requires webhook to be true")
+	}
+	methods, err := decodeHTTPRequestMethods(manifest.HttpRequestMethods)
+	if err != nil {
+		v.errorf("httpRequestMethods", "must be a list of HTTP methods: %s", err.Error())
+	}
+	for i, method := range methods {
+		if !validHTTPMethods[method] {
+			v.errorf(fmt.Sprintf("httpRequestMethods[%d]", i), "%q is not a valid HTTP method", method)
+		}
+	}
+	if len(methods) > 0 && !manifest.Webhook {
+		v.warnf("httpRequestMethods", "is ignored unless webhook is true")
+	}
+
+	if manifest.Auth != nil {
+		v.validateAuth(manifest.Auth, knownAuthServices)
+	}
+
+	quota, err := decodeComponentQuota(manifest.Quota)
+	if err != nil {
+		v.errorf("quota", "must be an object with manager, resources and scope: %s", err.Error())
+	} else if quota != nil && quota.Manager == "" {
+		v.errorf("quota.manager", "is required")
+	}
+
+	v.validatePorts("inPorts", manifest.InPorts)
+	v.validatePorts("outPorts", manifest.OutPorts)
+
+	if len(manifest.Properties) > 0 {
+		var props componentProperties
+		if err := json.Unmarshal(manifest.Properties, &props); err != nil {
+			v.errorf("properties", "must be an object with schema and inspector: %s", err.Error())
+		} else {
+			schemaProps := v.validateSchema("properties.schema", props.Schema)
+			v.validateInspector("properties.inspector", props.Inspector, schemaProps)
+		}
+	}
+
+	return &manifest, v.issues
+}
+
+func (v *manifestValidator) validateAuth(auth map[string]interface{}, knownAuthServices []string) {
+	service, ok := auth["service"].(string)
+	if !ok || service == "" {
+		v.errorf("auth.service", "is required when auth is set")
+		return
+	}
+	if !authServicePattern.MatchString(service) {
+		v.errorf("auth.service", "%q must have the form vendor:service", service)
+	}
+
+	if scope, ok := auth["scope"]; ok {
+		scopes, isList := scope.([]interface{})
+		if !isList {
+			v.errorf("auth.scope", "must be a list of strings")
+		}
+		for i, s := range scopes {
+			if _, isString := s.(string); !isString {
+				v.errorf(fmt.Sprintf("auth.scope[%d]", i), "must be a string")
+			}
+		}
+	}
+
+	if len(knownAuthServices) > 0 {
+		for _, known := range knownAuthServices {
+			if known == service {
+				return
+			}
+		}
+		v.errorf("auth.service", "references %q which is not a known auth service", service)
+	}
+}
+
+func (v *manifestValidator) validatePorts(p string, raw json.RawMessage) {
+	if len(raw) == 0 {
+		return
+	}
+
+	var rawPorts []json.RawMessage
+	if err := json.Unmarshal(raw, &rawPorts); err != nil {
+		v.errorf(p, "must be a list of port names or port objects")
+		return
+	}
+
+	seen := make(map[string]bool, len(rawPorts))
+	for i, rawPort := range rawPorts {
+		portPath := fmt.Sprintf("%s[%d]", p, i)
+
+		var port componentPort
+		if err := json.Unmarshal(rawPort, &port); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) && typeErr.Field != "" {
+				v.errorf(portPath+"."+typeErr.Field, "expected %s, got JSON %s", typeErr.Type.String(), typeErr.Value)
+			} else {
+				v.errorf(portPath, "must be a port name or a port object")
+			}
+			continue
+		}
+		if port.Name == "" {
+			v.errorf(portPath+".name", "is required")
+		} else if seen[port.Name] {
+			v.errorf(portPath+".name", "duplicate port name %q", port.Name)
+		}
+		seen[port.Name] = true
+
+		schemaProps := v.validateSchema(portPath+".schema", port.Schema)
+		v.validateInspector(portPath+".inspector", port.Inspector, schemaProps)
+
+		for j, opt := range port.Options {
+			optPath := fmt.Sprintf("%s.options[%d]", portPath, j)
+			if opt.Value == "" {
+				v.errorf(optPath+".value", "is required")
+			}
+			if opt.Label == "" {
+				v.warnf(optPath+".label", "is empty, the variable will be shown without a name")
+			}
+			if len(opt.Schema) > 0 {
+				v.validateSchema(optPath+".schema", opt.Schema)
+			}
+		}
+	}
+}
+
+// validateSchema checks the parts of a JSON schema Appmixer relies on and returns the
+// declared property names, or nil when the schema declares no properties
+func (v *manifestValidator) validateSchema(p string, raw json.RawMessage) map[string]bool {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var schemaDef map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &schemaDef); err != nil {
+		v.errorf(p, "must be a JSON schema object")
+		return nil
+	}
+
+	if rawType, ok := schemaDef["type"]; ok {
+		var single string
+		var multiple []string
+		if err := json.Unmarshal(rawType, &single); err == nil {
+			multiple = []string{single}
+		} else if err := json.Unmarshal(rawType, &multiple); err != nil {
+			v.errorf(p+".type", "must be a string or a list of strings")
+		}
+		for _, t := range multiple {
+			if !validSchemaTypes[t] {
+				v.errorf(p+".type", "%q is not a valid JSON schema type", t)
+			}
+		}
+	}
+
+	var props map[string]bool
+	if rawProps, ok := schemaDef["properties"]; ok {
+		var propDefs map[string]json.RawMessage
+		if err := json.Unmarshal(rawProps, &propDefs); err != nil {
+			v.errorf(p+".properties", "must be an object")
+		} else {
+			props = make(map[string]bool, len(propDefs))
+			for name := range propDefs {
+				props[name] = true
+			}
+		}
+	}
+
+	if rawRequired, ok := schemaDef["required"]; ok {
+		var required []string
+		if err := json.Unmarshal(rawRequired, &required); err != nil {
+			v.errorf(p+".required", "must be a list of property names")
+		} else if props != nil {
+			for i, name := range required {
+				if !props[name] {
+					v.errorf(fmt.Sprintf("%s.required[%d]", p, i), "%q is not declared in properties", name)
+				}
+			}
+		}
+	}
+
+	return props
+}
+
+func (v *manifestValidator) validateInspector(p string, inspector *componentInspector, schemaProps map[string]bool) {
+	if inspector == nil {
+		return
+	}
+
+	names := make([]string, 0, len(inspector.Inputs))
+	for name := range inspector.Inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		input := inspector.Inputs[name]
+		inputPath := fmt.Sprintf("%s.inputs.%s", p, name)
+
+		if input.Type == "" {
+			v.errorf(inputPath+".type", "is required")
+		} else if !knownInspectorTypes[input.Type] {
+			v.warnf(inputPath+".type", "%q is not a known inspector input type", input.Type)
+		}
+
+		if (input.Type == "select" || input.Type == "multiselect") && len(input.Options) == 0 && len(input.Source) == 0 {
+			v.warnf(inputPath, "%s input has neither options nor source", input.Type)
+		}
+
+		if input.Group != "" && len(inspector.Groups) > 0 {
+			if _, ok := inspector.Groups[input.Group]; !ok {
+				v.errorf(inputPath+".group", "references undefined group %q", input.Group)
+			}
+		}
+
+		if schemaProps != nil && !schemaProps[name] {
+			v.warnf(inputPath, "has no matching property in the schema")
+		}
+	}
+}
+
+// validateModulePackage validates every component.json inside a module archive. Components
+// whose auth service belongs to the packaged service must ship an auth.js in their service
+// directory, the directory above the module directory of the component.
+func validateModulePackage(pkg []byte) ([]manifestIssue, error) {
+	zr, err := zip.NewReader(bytes.NewReader(pkg), int64(len(pkg)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open module package: %w", err)
+	}
+
+	authDirs := make(map[string]bool)
+	var manifestFiles []*zip.File
+	for _, f := range zr.File {
+		switch path.Base(f.Name) {
+		case "auth.js":
+			authDirs[path.Dir(f.Name)] = true
+		case "component.json":
+			manifestFiles = append(manifestFiles, f)
+		}
+	}
+	sort.Slice(manifestFiles, func(i, j int) bool { return manifestFiles[i].Name < manifestFiles[j].Name })
+
+	var issues []manifestIssue
+	for _, f := range manifestFiles {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		// service/module/Component/component.json
+		serviceDir := path.Dir(path.Dir(path.Dir(f.Name)))
+		manifest, fileIssues := validateComponentManifest(content, nil)
+		if manifest != nil && manifest.Auth != nil && !authDirs[serviceDir] {
+			service, _ := manifest.Auth["service"].(string)
+			if isOwnAuthService(manifest.Name, service) {
+				fileIssues = append(fileIssues, manifestIssue{
+					Path:    "auth.service",
+					Message: fmt.Sprintf("references %q but the package contains no %s", service, path.Join(serviceDir, "auth.js")),
+				})
+			}
+		}
+
+		for _, issue := range fileIssues {
+			issue.Path = strings.TrimSuffix(f.Name+": "+issue.Path, ": ")
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues, nil
+}
+
+// isOwnAuthService reports whether an auth service (vendor:service) belongs to the same
+// vendor and service as the component (vendor.service.module.Component)
+func isOwnAuthService(componentName, service string) bool {
+	nameParts := strings.Split(componentName, ".")
+	serviceParts := strings.Split(service, ":")
+	if len(nameParts) < 2 || len(serviceParts) < 2 {
+		return false
+	}
+	return nameParts[0] == serviceParts[0] && nameParts[1] == serviceParts[1]
+}
+
+// splitManifestIssues separates errors from warnings
+func splitManifestIssues(issues []manifestIssue) (errs []string, warnings []string) {
+	errs = []string{}
+	warnings = []string{}
+	for _, issue := range issues {
+		if issue.Warning {
+			warnings = append(warnings, issue.String())
+		} else {
+			errs = append(errs, issue.String())
+		}
+	}
+	return errs, warnings
+}
+
+func offsetToLineColumn(data []byte, offset int64) (int, int) {
+	line, col := 1, 1
+	for i := int64(0); i < offset && i < int64(len(data)); i++ {
+		if data[i] == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return line, col
+}
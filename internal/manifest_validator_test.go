@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+func TestComponentNamePattern(t *testing.T) {
+	tests := map[string]bool{
+		"vendor.service.module.Component": true,
+		"appmixer.slack.list.SendMessage": true,
+		"vendor.service.Component":        false,
+		"vendor.service.module.sub.Comp":  false,
+		"vendor..module.Component":        false,
+	}
+	for name, valid := range tests {
+		if got := componentNamePattern.MatchString(name); got != valid {
+			t.Errorf("componentNamePattern.MatchString(%q) = %v, want %v", name, got, valid)
+		}
+	}
+}
+
+func TestValidateModulePackageAuthPerService(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, file := range []struct{ name, content string }{
+		{"alpha/auth.js", "module.exports = {};"},
+		{"alpha/core/Send/component.json", `{"name": "vendor.alpha.core.Send", "auth": {"service": "vendor:alpha"}}`},
+		// An auth.js next to the component does not count for the service
+		{"beta/core/Send/auth.js", "module.exports = {};"},
+		{"beta/core/Send/component.json", `{"name": "vendor.beta.core.Send", "auth": {"service": "vendor:beta"}}`},
+	} {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(file.content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := validateModulePackage(buf.Bytes())
+	if err != nil {
+		t.Fatalf("validation failed: %s", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("got %d issues, want 1: %v", len(issues), issues)
+	}
+	if got, want := issues[0].Path, "beta/core/Send/component.json: auth.service"; got != want {
+		t.Errorf("issue path is %q, want %q", got, want)
+	}
+}
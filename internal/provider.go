@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -40,17 +41,43 @@ func Provider() *schema.Provider {
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"appmixer_user":    resourceUser(),
-			"appmixer_account": resourceAccount(),
+			"appmixer_user":                        resourceUser(),
+			"appmixer_user_roster":                 resourceUserRoster(),
+			"appmixer_account":                     resourceAccount(),
+			"appmixer_module":                      resourceModule(),
+			"appmixer_service_config":              resourceServiceConfig(),
+			"appmixer_data_store":                  resourceDataStore(),
+			"appmixer_data_store_item":             resourceDataStoreItem(),
+			"appmixer_data_store_items":            resourceDataStoreItems(),
+			"appmixer_data_store_seed":             resourceDataStoreSeed(),
+			"appmixer_acl_rule":                    resourceACLRule(),
+			"appmixer_system_config":               resourceSystemConfig(),
+			"appmixer_integration_template":        resourceIntegrationTemplate(),
+			"appmixer_integration_instance":        resourceIntegrationInstance(),
+			"appmixer_flow_share":                  resourceFlowShare(),
+			"appmixer_unprocessed_messages_action": resourceUnprocessedMessagesAction(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"appmixer_user":           dataSourceUser(),
-			"appmixer_users":          dataSourceUsers(),
-			"appmixer_users_count":    dataSourceUsersCount(),
-			"appmixer_account":        dataSourceAccount(),
-			"appmixer_accounts":       dataSourceAccounts(),
-			"appmixer_apps":           dataSourceApps(),
-			"appmixer_app_components": dataSourceAppComponents(),
+			"appmixer_user":                 dataSourceUser(),
+			"appmixer_users":                dataSourceUsers(),
+			"appmixer_users_count":          dataSourceUsersCount(),
+			"appmixer_account":              dataSourceAccount(),
+			"appmixer_accounts":             dataSourceAccounts(),
+			"appmixer_acl_types":            dataSourceACLTypes(),
+			"appmixer_app":                  dataSourceApp(),
+			"appmixer_apps":                 dataSourceApps(),
+			"appmixer_app_components":       dataSourceAppComponents(),
+			"appmixer_plans":                dataSourcePlans(),
+			"appmixer_system_configs":       dataSourceSystemConfigs(),
+			"appmixer_component_manifest":   dataSourceComponentManifest(),
+			"appmixer_data_store_items":     dataSourceDataStoreItems(),
+			"appmixer_flow_bundle":          dataSourceFlowBundle(),
+			"appmixer_flow_descriptor":      dataSourceFlowDescriptor(),
+			"appmixer_flow_export":          dataSourceFlowExport(),
+			"appmixer_flow_logs":            dataSourceFlowLogs(),
+			"appmixer_flow_variables":       dataSourceFlowVariables(),
+			"appmixer_flows":                dataSourceFlows(),
+			"appmixer_unprocessed_messages": dataSourceUnprocessedMessages(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -64,6 +91,10 @@ type Client struct {
 	AuthToken  string
 	Scope      []string // Add user scope to check for admin permissions
 	HTTPClient *http.Client
+
+	// Access tokens of users the client acts on behalf of, see ForUser
+	userTokens   map[string]string
+	userTokensMu sync.Mutex
 }
 
 type authRequest struct {
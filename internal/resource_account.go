@@ -48,7 +48,7 @@ func resourceAccount() *schema.Resource {
 		UpdateContext: resourceAccountUpdate,
 		DeleteContext: resourceAccountDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext, // Import using accountId
+			StateContext: resourceAccountImport, // Import using [ownerUserId@]accountId
 		},
 		Schema: map[string]*schema.Schema{
 			"service": {
@@ -73,6 +73,13 @@ func resourceAccount() *schema.Resource {
 				Computed:    true, // Computed because the API might return it even if not set
 				Description: "An optional user-friendly name for the account.",
 			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, destroying the account fails. Must be explicitly disabled and applied before the account can be destroyed.",
+			},
+			"owner_user_id": ownerUserIDSchema("account"),
 			// Computed fields read from the API
 			"name": {
 				Type:        schema.TypeString,
@@ -107,7 +114,10 @@ func resourceAccount() *schema.Resource {
 }
 
 func resourceAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*Client)
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	service := d.Get("service").(string)
 	tokenInput := d.Get("token").(map[string]interface{})
@@ -174,7 +184,10 @@ func resourceAccountCreate(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*Client)
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	accountID := d.Id()
 	var diags diag.Diagnostics
 
@@ -263,7 +276,10 @@ func resourceAccountRead(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*Client)
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	accountID := d.Id()
 
 	tflog.Info(ctx, "Updating Appmixer account", map[string]interface{}{
@@ -297,7 +313,10 @@ func resourceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceAccountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*Client)
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	accountID := d.Id()
 	var diags diag.Diagnostics
 
@@ -305,7 +324,11 @@ func resourceAccountDelete(ctx context.Context, d *schema.ResourceData, m interf
 		"account_id": accountID,
 	})
 
-	_, err := client.DoRequest(ctx, "DELETE", fmt.Sprintf("/accounts/%s", accountID), nil)
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("Account %s is protected from deletion. Set deletion_protection = false and apply before destroying it", accountID)
+	}
+
+	_, err = client.DoRequest(ctx, "DELETE", fmt.Sprintf("/accounts/%s", accountID), nil)
 	if err != nil {
 		// Check if already deleted (404) - Allow delete to succeed if already gone
 		if strings.Contains(err.Error(), "status 404") {
@@ -321,3 +344,10 @@ func resourceAccountDelete(ctx context.Context, d *schema.ResourceData, m interf
 	tflog.Info(ctx, "Successfully deleted account", map[string]interface{}{"account_id": accountID})
 	return diags
 }
+
+func resourceAccountImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, err := splitOwnerImportID(d, "owner_user_id"); err != nil {
+		return nil, err
+	}
+	return importWithDeletionProtection(ctx, d, m)
+}
@@ -0,0 +1,264 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ACL types supported by Appmixer: component access and API route access
+var aclTypes = []string{"components", "routers"}
+
+// The ACL API only replaces the whole rule list of a type, so rule changes of one
+// apply are serialized to avoid losing concurrent edits
+var aclMutex sync.Mutex
+
+// Represents a rule from the GET /acl/:type API
+type aclRule struct {
+	Role       string   `json:"role"`
+	Resource   string   `json:"resource"`
+	Action     []string `json:"action"`
+	Attributes []string `json:"attributes"`
+}
+
+func resourceACLRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceACLRuleCreate,
+		ReadContext:   resourceACLRuleRead,
+		UpdateContext: resourceACLRuleUpdate,
+		DeleteContext: resourceACLRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceACLRuleImport, // Import using type/role/resource
+		},
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(aclTypes, false),
+				Description:  "The ACL type: 'components' for component access or 'routers' for API route access.",
+			},
+			"role": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The role the rule applies to, matched against the scope of users (e.g., 'user', 'admin' or a custom scope).",
+			},
+			"resource": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The component pattern (e.g., 'appmixer.utils.*') or route (e.g., 'flows') the rule applies to. '*' matches everything.",
+			},
+			"action": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Allowed actions (e.g., '*', 'use', 'read').",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"attributes": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Attribute restrictions of the rule (e.g., 'non-private', 'private' or 'vendor' to only match components of the user's vendor).",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func aclRuleID(aclType, role, resource string) string {
+	return fmt.Sprintf("%s/%s/%s", aclType, role, resource)
+}
+
+func fetchACLRules(ctx context.Context, client *Client, aclType string) ([]aclRule, error) {
+	respBytes, err := client.DoRequest(ctx, "GET", fmt.Sprintf("/acl/%s", aclType), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s ACL: %w", aclType, err)
+	}
+
+	var rules []aclRule
+	if err := json.Unmarshal(respBytes, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse %s ACL: %w", aclType, err)
+	}
+	return rules, nil
+}
+
+func findACLRule(rules []aclRule, role, resource string) int {
+	for i, rule := range rules {
+		if rule.Role == role && rule.Resource == resource {
+			return i
+		}
+	}
+	return -1
+}
+
+// modifyACLRules applies modify to the current rules of a type and saves the result
+func modifyACLRules(ctx context.Context, client *Client, aclType string, modify func(rules []aclRule) ([]aclRule, error)) error {
+	aclMutex.Lock()
+	defer aclMutex.Unlock()
+
+	rules, err := fetchACLRules(ctx, client, aclType)
+	if err != nil {
+		return err
+	}
+
+	rules, err = modify(rules)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.DoRequest(ctx, "POST", fmt.Sprintf("/acl/%s", aclType), rules); err != nil {
+		return fmt.Errorf("failed to save %s ACL: %w", aclType, err)
+	}
+	return nil
+}
+
+func expandACLRule(d *schema.ResourceData) aclRule {
+	return aclRule{
+		Role:       d.Get("role").(string),
+		Resource:   d.Get("resource").(string),
+		Action:     expandStringList(d.Get("action")),
+		Attributes: expandStringList(d.Get("attributes")),
+	}
+}
+
+func resourceACLRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	aclType := d.Get("type").(string)
+	rule := expandACLRule(d)
+
+	if !hasAdminPermissions(client) {
+		return diag.Errorf("Managing ACL rules requires admin permissions")
+	}
+
+	tflog.Info(ctx, "Creating Appmixer ACL rule", map[string]interface{}{
+		"type":     aclType,
+		"role":     rule.Role,
+		"resource": rule.Resource,
+	})
+
+	err := modifyACLRules(ctx, client, aclType, func(rules []aclRule) ([]aclRule, error) {
+		if findACLRule(rules, rule.Role, rule.Resource) >= 0 {
+			return nil, fmt.Errorf("%s ACL rule for role %s and resource %s already exists, import it with ID %s", aclType, rule.Role, rule.Resource, aclRuleID(aclType, rule.Role, rule.Resource))
+		}
+		return append(rules, rule), nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(aclRuleID(aclType, rule.Role, rule.Resource))
+
+	return resourceACLRuleRead(ctx, d, m)
+}
+
+func resourceACLRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	aclType := d.Get("type").(string)
+	role := d.Get("role").(string)
+	resource := d.Get("resource").(string)
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Reading Appmixer ACL rule", map[string]interface{}{
+		"id": d.Id(),
+	})
+
+	rules, err := fetchACLRules(ctx, client, aclType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	idx := findACLRule(rules, role, resource)
+	if idx < 0 {
+		tflog.Warn(ctx, "ACL rule not found, removing from state", map[string]interface{}{"id": d.Id()})
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("action", rules[idx].Action); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set action: %w", err))
+	}
+	if err := d.Set("attributes", rules[idx].Attributes); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set attributes: %w", err))
+	}
+
+	return diags
+}
+
+func resourceACLRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	aclType := d.Get("type").(string)
+	rule := expandACLRule(d)
+
+	if !hasAdminPermissions(client) {
+		return diag.Errorf("Managing ACL rules requires admin permissions")
+	}
+
+	if d.HasChanges("action", "attributes") {
+		tflog.Info(ctx, "Updating Appmixer ACL rule", map[string]interface{}{
+			"id": d.Id(),
+		})
+
+		err := modifyACLRules(ctx, client, aclType, func(rules []aclRule) ([]aclRule, error) {
+			idx := findACLRule(rules, rule.Role, rule.Resource)
+			if idx < 0 {
+				return append(rules, rule), nil
+			}
+			rules[idx] = rule
+			return rules, nil
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceACLRuleRead(ctx, d, m)
+}
+
+func resourceACLRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	aclType := d.Get("type").(string)
+	role := d.Get("role").(string)
+	resource := d.Get("resource").(string)
+	var diags diag.Diagnostics
+
+	tflog.Info(ctx, "Deleting Appmixer ACL rule", map[string]interface{}{
+		"id": d.Id(),
+	})
+
+	err := modifyACLRules(ctx, client, aclType, func(rules []aclRule) ([]aclRule, error) {
+		idx := findACLRule(rules, role, resource)
+		if idx < 0 {
+			// Already gone
+			return rules, nil
+		}
+		return append(rules[:idx], rules[idx+1:]...), nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceACLRuleImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Resources may contain slashes, types and roles never do
+	parts := strings.SplitN(d.Id(), "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("unexpected import ID %q, expected <type>/<role>/<resource>", d.Id())
+	}
+
+	d.Set("type", parts[0])
+	d.Set("role", parts[1])
+	d.Set("resource", parts[2])
+	return []*schema.ResourceData{d}, nil
+}
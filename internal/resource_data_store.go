@@ -0,0 +1,232 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Represents a data store from the GET /stores API
+type dataStoreResponse struct {
+	StoreID string `json:"storeId"`
+	Name    string `json:"name"`
+	UserID  string `json:"userId"`
+}
+
+// Represents a single item of a data store from the GET /store API
+type dataStoreItem struct {
+	Key       string          `json:"key"`
+	Value     json.RawMessage `json:"value"`
+	StoreID   string          `json:"storeId"`
+	CreatedAt string          `json:"createdAt"`
+	UpdatedAt string          `json:"updatedAt"`
+}
+
+func resourceDataStore() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDataStoreCreate,
+		ReadContext:   resourceDataStoreRead,
+		UpdateContext: resourceDataStoreUpdate,
+		DeleteContext: resourceDataStoreDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importOwnedPassthrough, // Import using [ownerUserId@]storeId
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the data store.",
+			},
+			"owner_user_id": ownerUserIDSchema("data store"),
+			// Computed fields read from the API
+			"user_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Appmixer user ID owning the data store.",
+			},
+		},
+	}
+}
+
+func dataStoreItemPath(storeID, key string) string {
+	return fmt.Sprintf("/store/%s/%s", url.PathEscape(storeID), url.PathEscape(key))
+}
+
+// putDataStoreItem creates or replaces a single item. value must be valid JSON.
+func putDataStoreItem(ctx context.Context, client *Client, storeID, key, value string) error {
+	_, err := client.DoRawRequest(ctx, "PUT", dataStoreItemPath(storeID, key), "application/json", []byte(value))
+	if err != nil {
+		return fmt.Errorf("failed to set item %s in data store %s: %w", key, storeID, err)
+	}
+	return nil
+}
+
+// deleteDataStoreItem deletes a single item, treating an already missing item as success
+func deleteDataStoreItem(ctx context.Context, client *Client, storeID, key string) error {
+	_, err := client.DoRequest(ctx, "DELETE", dataStoreItemPath(storeID, key), nil)
+	if err != nil && !strings.Contains(err.Error(), "status 404") {
+		return fmt.Errorf("failed to delete item %s from data store %s: %w", key, storeID, err)
+	}
+	return nil
+}
+
+// listDataStoreItems fetches one page of items. A limit of 0 fetches all items page by page.
+func listDataStoreItems(ctx context.Context, client *Client, storeID, pattern, sort string, limit, offset int) ([]dataStoreItem, error) {
+	pageSize := limit
+	if limit == 0 {
+		pageSize = 100
+	}
+
+	var items []dataStoreItem
+	for {
+		queryParams := url.Values{}
+		queryParams.Add("storeId", storeID)
+		queryParams.Add("limit", fmt.Sprintf("%d", pageSize))
+		queryParams.Add("offset", fmt.Sprintf("%d", offset))
+		if pattern != "" {
+			queryParams.Add("pattern", pattern)
+		}
+		if sort != "" {
+			queryParams.Add("sort", sort)
+		}
+
+		respBytes, err := client.DoRequest(ctx, "GET", "/store?"+queryParams.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list items of data store %s: %w", storeID, err)
+		}
+
+		var page []dataStoreItem
+		if err := json.Unmarshal(respBytes, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse items of data store %s: %w", storeID, err)
+		}
+		items = append(items, page...)
+
+		if limit != 0 || len(page) < pageSize {
+			return items, nil
+		}
+		offset += pageSize
+	}
+}
+
+func resourceDataStoreCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name := d.Get("name").(string)
+
+	tflog.Info(ctx, "Creating Appmixer data store", map[string]interface{}{
+		"name": name,
+	})
+
+	respBytes, err := client.DoRequest(ctx, "POST", "/stores", map[string]string{"name": name})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create data store %s: %w", name, err))
+	}
+
+	var createRes dataStoreResponse
+	if err := json.Unmarshal(respBytes, &createRes); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to parse create data store response: %w", err))
+	}
+
+	if createRes.StoreID == "" {
+		return diag.Errorf("API did not return a storeId after creating data store %s", name)
+	}
+
+	d.SetId(createRes.StoreID)
+
+	return resourceDataStoreRead(ctx, d, m)
+}
+
+func resourceDataStoreRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	storeID := d.Id()
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Reading Appmixer data store", map[string]interface{}{
+		"store_id": storeID,
+	})
+
+	respBytes, err := client.DoRequest(ctx, "GET", fmt.Sprintf("/stores/%s", storeID), nil)
+	if err != nil {
+		if strings.Contains(err.Error(), "status 404") {
+			tflog.Warn(ctx, "Data store not found, removing from state", map[string]interface{}{"store_id": storeID})
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(fmt.Errorf("failed to read data store %s: %w", storeID, err))
+	}
+
+	var store dataStoreResponse
+	if err := json.Unmarshal(respBytes, &store); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to parse data store %s: %w", storeID, err))
+	}
+
+	if err := d.Set("name", store.Name); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set name: %w", err))
+	}
+	if err := d.Set("user_id", store.UserID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set user_id: %w", err))
+	}
+
+	return diags
+}
+
+func resourceDataStoreUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	storeID := d.Id()
+
+	if d.HasChange("name") {
+		name := d.Get("name").(string)
+		tflog.Info(ctx, "Renaming Appmixer data store", map[string]interface{}{
+			"store_id": storeID,
+			"name":     name,
+		})
+
+		_, err := client.DoRequest(ctx, "PUT", fmt.Sprintf("/stores/%s", storeID), map[string]string{"name": name})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to rename data store %s: %w", storeID, err))
+		}
+	}
+
+	return resourceDataStoreRead(ctx, d, m)
+}
+
+func resourceDataStoreDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	storeID := d.Id()
+	var diags diag.Diagnostics
+
+	tflog.Info(ctx, "Deleting Appmixer data store", map[string]interface{}{
+		"store_id": storeID,
+	})
+
+	_, err = client.DoRequest(ctx, "DELETE", fmt.Sprintf("/stores/%s", storeID), nil)
+	if err != nil {
+		// Allow delete to succeed if already gone
+		if strings.Contains(err.Error(), "status 404") {
+			tflog.Warn(ctx, "Data store already deleted", map[string]interface{}{"store_id": storeID})
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(fmt.Errorf("failed to delete data store %s: %w", storeID, err))
+	}
+
+	d.SetId("")
+	return diags
+}
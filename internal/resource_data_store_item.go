@@ -0,0 +1,198 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDataStoreItem() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDataStoreItemCreate,
+		ReadContext:   resourceDataStoreItemRead,
+		UpdateContext: resourceDataStoreItemUpdate,
+		DeleteContext: resourceDataStoreItemDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDataStoreItemImport, // Import using [ownerUserId@]storeId/key
+		},
+		Schema: map[string]*schema.Schema{
+			"store_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the data store.",
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the item.",
+			},
+			"value": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJSON,
+				Description:      "The JSON encoded value of the item. Use jsonencode() to store strings, numbers or objects.",
+			},
+			"owner_user_id": ownerUserIDSchema("item"),
+			// Computed fields read from the API
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// jsonEquivalent reports whether two JSON documents decode to the same value
+func jsonEquivalent(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var va, vb interface{}
+	if err := json.Unmarshal([]byte(a), &va); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(b), &vb); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// suppressEquivalentJSON ignores formatting and key order differences in JSON attributes
+func suppressEquivalentJSON(k, old, new string, d *schema.ResourceData) bool {
+	return jsonEquivalent(old, new)
+}
+
+func resourceDataStoreItemCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	storeID := d.Get("store_id").(string)
+	key := d.Get("key").(string)
+
+	tflog.Info(ctx, "Creating Appmixer data store item", map[string]interface{}{
+		"store_id": storeID,
+		"key":      key,
+	})
+
+	if err := putDataStoreItem(ctx, client, storeID, key, d.Get("value").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", storeID, key))
+
+	return resourceDataStoreItemRead(ctx, d, m)
+}
+
+func resourceDataStoreItemRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	storeID := d.Get("store_id").(string)
+	key := d.Get("key").(string)
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Reading Appmixer data store item", map[string]interface{}{
+		"store_id": storeID,
+		"key":      key,
+	})
+
+	respBytes, err := client.DoRequest(ctx, "GET", dataStoreItemPath(storeID, key), nil)
+	if err != nil {
+		if strings.Contains(err.Error(), "status 404") {
+			tflog.Warn(ctx, "Data store item not found, removing from state", map[string]interface{}{"store_id": storeID, "key": key})
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(fmt.Errorf("failed to read item %s of data store %s: %w", key, storeID, err))
+	}
+
+	var item dataStoreItem
+	if err := json.Unmarshal(respBytes, &item); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to parse item %s of data store %s: %w", key, storeID, err))
+	}
+
+	if err := d.Set("value", string(item.Value)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set value: %w", err))
+	}
+	d.Set("created_at", item.CreatedAt)
+	d.Set("updated_at", item.UpdatedAt)
+
+	return diags
+}
+
+func resourceDataStoreItemUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	storeID := d.Get("store_id").(string)
+	key := d.Get("key").(string)
+
+	if d.HasChange("value") {
+		tflog.Info(ctx, "Updating Appmixer data store item", map[string]interface{}{
+			"store_id": storeID,
+			"key":      key,
+		})
+
+		if err := putDataStoreItem(ctx, client, storeID, key, d.Get("value").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceDataStoreItemRead(ctx, d, m)
+}
+
+func resourceDataStoreItemDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	storeID := d.Get("store_id").(string)
+	key := d.Get("key").(string)
+	var diags diag.Diagnostics
+
+	tflog.Info(ctx, "Deleting Appmixer data store item", map[string]interface{}{
+		"store_id": storeID,
+		"key":      key,
+	})
+
+	if err := deleteDataStoreItem(ctx, client, storeID, key); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceDataStoreItemImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id, err := splitOwnerImportID(d, "owner_user_id")
+	if err != nil {
+		return nil, err
+	}
+
+	// Keys may contain slashes, store IDs never do
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected import ID %q, expected <store_id>/<key>", d.Id())
+	}
+
+	d.Set("store_id", parts[0])
+	d.Set("key", parts[1])
+	return []*schema.ResourceData{d}, nil
+}
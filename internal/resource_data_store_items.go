@@ -0,0 +1,268 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDataStoreItems() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDataStoreItemsCreate,
+		ReadContext:   resourceDataStoreItemsRead,
+		UpdateContext: resourceDataStoreItemsUpdate,
+		DeleteContext: resourceDataStoreItemsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDataStoreItemsImport, // Import using [ownerUserId@]storeId, adopts all items of the store
+		},
+		Schema: map[string]*schema.Schema{
+			"store_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the data store.",
+			},
+			"items": {
+				Type:             schema.TypeMap,
+				Required:         true,
+				DiffSuppressFunc: suppressEquivalentJSON,
+				Description:      "Items of the data store, keyed by item key. Values are JSON encoded.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsJSON,
+				},
+			},
+			"owner_user_id": ownerUserIDSchema("items"),
+			"exclusive": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether this resource owns the whole data store. When true, items not listed in items are deleted; otherwise they are left untouched.",
+			},
+		},
+	}
+}
+
+// diffDataStoreItems returns the items to write and the keys to delete to turn current into desired.
+// Values are compared as JSON so formatting differences do not cause writes.
+func diffDataStoreItems(current, desired map[string]string) (map[string]string, []string) {
+	upserts := make(map[string]string)
+	for key, value := range desired {
+		if currentValue, ok := current[key]; !ok || !jsonEquivalent(currentValue, value) {
+			upserts[key] = value
+		}
+	}
+
+	var deletes []string
+	for key := range current {
+		if _, ok := desired[key]; !ok {
+			deletes = append(deletes, key)
+		}
+	}
+	sort.Strings(deletes)
+
+	return upserts, deletes
+}
+
+// applyDataStoreItemChanges writes upserts and deletes keys in key order, stopping at the first failure
+func applyDataStoreItemChanges(ctx context.Context, client *Client, storeID string, upserts map[string]string, deletes []string) error {
+	keys := make([]string, 0, len(upserts))
+	for key := range upserts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := putDataStoreItem(ctx, client, storeID, key, upserts[key]); err != nil {
+			return err
+		}
+	}
+	for _, key := range deletes {
+		if err := deleteDataStoreItem(ctx, client, storeID, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchDataStoreValues returns all items of a store as key to JSON value
+func fetchDataStoreValues(ctx context.Context, client *Client, storeID string) (map[string]string, error) {
+	items, err := listDataStoreItems(ctx, client, storeID, "", "", 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(items))
+	for _, item := range items {
+		values[item.Key] = string(item.Value)
+	}
+	return values, nil
+}
+
+func expandDataStoreValues(v interface{}) map[string]string {
+	raw, _ := v.(map[string]interface{})
+	values := make(map[string]string, len(raw))
+	for k, v := range raw {
+		values[k] = v.(string)
+	}
+	return values
+}
+
+func resourceDataStoreItemsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	storeID := d.Get("store_id").(string)
+	desired := expandDataStoreValues(d.Get("items"))
+
+	current := map[string]string{}
+	if d.Get("exclusive").(bool) {
+		var err error
+		if current, err = fetchDataStoreValues(ctx, client, storeID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	upserts, deletes := diffDataStoreItems(current, desired)
+
+	tflog.Info(ctx, "Creating Appmixer data store items", map[string]interface{}{
+		"store_id": storeID,
+		"upserts":  len(upserts),
+		"deletes":  len(deletes),
+	})
+
+	if err := applyDataStoreItemChanges(ctx, client, storeID, upserts, deletes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(storeID)
+
+	return resourceDataStoreItemsRead(ctx, d, m)
+}
+
+func resourceDataStoreItemsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	storeID := d.Id()
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Reading Appmixer data store items", map[string]interface{}{
+		"store_id": storeID,
+	})
+
+	remote, err := fetchDataStoreValues(ctx, client, storeID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Only track the managed keys unless the resource owns the whole store. Keep the
+	// configured formatting of values that did not change.
+	prior := expandDataStoreValues(d.Get("items"))
+	items := make(map[string]string)
+	for key, value := range remote {
+		priorValue, managed := prior[key]
+		if !managed && !d.Get("exclusive").(bool) {
+			continue
+		}
+		if managed && jsonEquivalent(priorValue, value) {
+			value = priorValue
+		}
+		items[key] = value
+	}
+
+	if err := d.Set("store_id", storeID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set store_id: %w", err))
+	}
+	if err := d.Set("items", items); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set items: %w", err))
+	}
+
+	return diags
+}
+
+func resourceDataStoreItemsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	storeID := d.Id()
+
+	if d.HasChanges("items", "exclusive") {
+		oldItems, newItems := d.GetChange("items")
+		current := expandDataStoreValues(oldItems)
+		if d.Get("exclusive").(bool) {
+			var err error
+			if current, err = fetchDataStoreValues(ctx, client, storeID); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		upserts, deletes := diffDataStoreItems(current, expandDataStoreValues(newItems))
+
+		tflog.Info(ctx, "Updating Appmixer data store items", map[string]interface{}{
+			"store_id": storeID,
+			"upserts":  len(upserts),
+			"deletes":  len(deletes),
+		})
+
+		if err := applyDataStoreItemChanges(ctx, client, storeID, upserts, deletes); err != nil {
+			// Keep the prior state so the remaining changes are planned again
+			d.Partial(true)
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceDataStoreItemsRead(ctx, d, m)
+}
+
+func resourceDataStoreItemsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	storeID := d.Id()
+	var diags diag.Diagnostics
+
+	keys := make([]string, 0)
+	for key := range expandDataStoreValues(d.Get("items")) {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	tflog.Info(ctx, "Deleting Appmixer data store items", map[string]interface{}{
+		"store_id": storeID,
+		"count":    len(keys),
+	})
+
+	if err := applyDataStoreItemChanges(ctx, client, storeID, nil, keys); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceDataStoreItemsImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, err := splitOwnerImportID(d, "owner_user_id"); err != nil {
+		return nil, err
+	}
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := fetchDataStoreValues(ctx, client, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("store_id", d.Id())
+	d.Set("items", items)
+	return []*schema.ResourceData{d}, nil
+}
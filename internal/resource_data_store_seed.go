@@ -0,0 +1,436 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDataStoreSeed() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDataStoreSeedApply,
+		ReadContext:   resourceDataStoreSeedRead,
+		UpdateContext: resourceDataStoreSeedApply,
+		DeleteContext: resourceDataStoreSeedDelete,
+		CustomizeDiff: resourceDataStoreSeedCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"store_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the data store. The seed owns all items of the store.",
+			},
+			"source": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path of the local CSV or JSON file with the items.",
+			},
+			"format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"csv", "json"}, false),
+				Description:  "Format of the source file, 'csv' or 'json'. Derived from the file extension when not set.",
+			},
+			"key_column": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Column (CSV) or field (JSON array of objects) holding the item key. Required unless the source is a JSON object keyed by item key.",
+			},
+			"value_column": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Column or field holding the item value. When not set, the value is an object of all other columns of the row.",
+			},
+			"owner_user_id": ownerUserIDSchema("items"),
+			// Computed fields
+			"items_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 of the store contents. Changes of the source file or of the store outside of Terraform change the hash and plan a new seeding.",
+			},
+			"item_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of items in the data store.",
+			},
+			"added": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of items added by the last seeding.",
+			},
+			"changed": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of items updated by the last seeding.",
+			},
+			"removed": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of items deleted by the last seeding.",
+			},
+		},
+	}
+}
+
+// loadSeedItems parses the source file into item key to JSON encoded value
+func loadSeedItems(source, format, keyColumn, valueColumn string) (map[string]string, error) {
+	content, err := os.ReadFile(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seed file %s: %w", source, err)
+	}
+
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(source)), ".")
+	}
+
+	switch format {
+	case "csv":
+		return parseSeedCSV(source, content, keyColumn, valueColumn)
+	case "json":
+		return parseSeedJSON(source, content, keyColumn, valueColumn)
+	default:
+		return nil, fmt.Errorf("cannot derive the format of seed file %s, set format to 'csv' or 'json'", source)
+	}
+}
+
+func parseSeedCSV(source string, content []byte, keyColumn, valueColumn string) (map[string]string, error) {
+	if keyColumn == "" {
+		return nil, fmt.Errorf("key_column is required for CSV seed file %s", source)
+	}
+
+	r := csv.NewReader(bytes.NewReader(content))
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header of seed file %s: %w", source, err)
+	}
+
+	keyIndex, valueIndex := -1, -1
+	for i, column := range header {
+		if column == keyColumn {
+			keyIndex = i
+		}
+		if valueColumn != "" && column == valueColumn {
+			valueIndex = i
+		}
+	}
+	if keyIndex < 0 {
+		return nil, fmt.Errorf("seed file %s has no column %q", source, keyColumn)
+	}
+	if valueColumn != "" && valueIndex < 0 {
+		return nil, fmt.Errorf("seed file %s has no column %q", source, valueColumn)
+	}
+
+	items := make(map[string]string)
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			return items, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse seed file %s: %w", source, err)
+		}
+
+		var value interface{}
+		if valueIndex >= 0 {
+			value = record[valueIndex]
+		} else {
+			row := make(map[string]string, len(header)-1)
+			for i, column := range header {
+				if i != keyIndex {
+					row[column] = record[i]
+				}
+			}
+			value = row
+		}
+
+		if err := addSeedItem(items, record[keyIndex], value); err != nil {
+			return nil, fmt.Errorf("seed file %s line %d: %w", source, line, err)
+		}
+	}
+}
+
+func parseSeedJSON(source string, content []byte, keyColumn, valueColumn string) (map[string]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.UseNumber()
+	var data interface{}
+	if err := dec.Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to parse seed file %s: %w", source, err)
+	}
+
+	items := make(map[string]string)
+	switch data := data.(type) {
+	case map[string]interface{}:
+		// Object keyed by item key
+		for key, value := range data {
+			if err := addSeedItem(items, key, value); err != nil {
+				return nil, fmt.Errorf("seed file %s: %w", source, err)
+			}
+		}
+	case []interface{}:
+		if keyColumn == "" {
+			return nil, fmt.Errorf("key_column is required for seed file %s containing an array", source)
+		}
+		for i, element := range data {
+			row, ok := element.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("seed file %s: [%d] must be an object", source, i)
+			}
+			key, ok := row[keyColumn].(string)
+			if !ok {
+				return nil, fmt.Errorf("seed file %s: [%d].%s must be a string", source, i, keyColumn)
+			}
+
+			var value interface{}
+			if valueColumn != "" {
+				if value, ok = row[valueColumn]; !ok {
+					return nil, fmt.Errorf("seed file %s: [%d] has no field %q", source, i, valueColumn)
+				}
+			} else {
+				rest := make(map[string]interface{}, len(row)-1)
+				for field, v := range row {
+					if field != keyColumn {
+						rest[field] = v
+					}
+				}
+				value = rest
+			}
+
+			if err := addSeedItem(items, key, value); err != nil {
+				return nil, fmt.Errorf("seed file %s: [%d]: %w", source, i, err)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("seed file %s must contain a JSON object or an array of objects", source)
+	}
+
+	return items, nil
+}
+
+func addSeedItem(items map[string]string, key string, value interface{}) error {
+	if key == "" {
+		return fmt.Errorf("empty item key")
+	}
+	if _, ok := items[key]; ok {
+		return fmt.Errorf("duplicate item key %q", key)
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode value of item %q: %w", key, err)
+	}
+	items[key] = string(encoded)
+	return nil
+}
+
+// dataStoreItemsHash hashes items independently of key order and JSON formatting
+func dataStoreItemsHash(items map[string]string) string {
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, key := range keys {
+		value := items[key]
+		dec := json.NewDecoder(strings.NewReader(value))
+		dec.UseNumber()
+		var decoded interface{}
+		if err := dec.Decode(&decoded); err == nil {
+			if canonical, err := json.Marshal(decoded); err == nil {
+				value = string(canonical)
+			}
+		}
+		fmt.Fprintf(h, "%s\x00%s\x00", key, value)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// countSeedChanges splits the upserts into added and changed items
+func countSeedChanges(current, upserts map[string]string) (int, int) {
+	added, changed := 0, 0
+	for key := range upserts {
+		if _, ok := current[key]; ok {
+			changed++
+		} else {
+			added++
+		}
+	}
+	return added, changed
+}
+
+func resourceDataStoreSeedCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	client, err := m.(*Client).ForUser(ctx, diff.Get("owner_user_id").(string))
+	if err != nil {
+		return err
+	}
+
+	// Sources computed from other resources are only known during apply
+	for _, k := range []string{"source", "format", "key_column", "value_column"} {
+		if !diff.NewValueKnown(k) {
+			return setSeedCountsComputed(diff)
+		}
+	}
+
+	desired, err := loadSeedItems(diff.Get("source").(string), diff.Get("format").(string), diff.Get("key_column").(string), diff.Get("value_column").(string))
+	if err != nil {
+		return err
+	}
+
+	hash := dataStoreItemsHash(desired)
+	if hash == diff.Get("items_hash").(string) {
+		return nil
+	}
+	if err := diff.SetNew("items_hash", hash); err != nil {
+		return err
+	}
+
+	// The counts can only be planned when the store already exists
+	if !diff.NewValueKnown("store_id") {
+		return setSeedCountsComputed(diff)
+	}
+	storeID := diff.Get("store_id").(string)
+	current, err := fetchDataStoreValues(ctx, client, storeID)
+	if err != nil {
+		return err
+	}
+
+	upserts, deletes := diffDataStoreItems(current, desired)
+	added, changed := countSeedChanges(current, upserts)
+
+	tflog.Debug(ctx, "Planning data store seeding", map[string]interface{}{
+		"store_id": storeID,
+		"added":    added,
+		"changed":  changed,
+		"removed":  len(deletes),
+	})
+
+	diff.SetNew("item_count", len(desired))
+	diff.SetNew("added", added)
+	diff.SetNew("changed", changed)
+	return diff.SetNew("removed", len(deletes))
+}
+
+func setSeedCountsComputed(diff *schema.ResourceDiff) error {
+	for _, k := range []string{"items_hash", "item_count", "added", "changed", "removed"} {
+		if err := diff.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceDataStoreSeedApply(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	storeID := d.Get("store_id").(string)
+
+	desired, err := loadSeedItems(d.Get("source").(string), d.Get("format").(string), d.Get("key_column").(string), d.Get("value_column").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	current, err := fetchDataStoreValues(ctx, client, storeID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	upserts, deletes := diffDataStoreItems(current, desired)
+	added, changed := countSeedChanges(current, upserts)
+
+	tflog.Info(ctx, "Seeding Appmixer data store", map[string]interface{}{
+		"store_id": storeID,
+		"added":    added,
+		"changed":  changed,
+		"removed":  len(deletes),
+	})
+
+	if err := applyDataStoreItemChanges(ctx, client, storeID, upserts, deletes); err != nil {
+		// Keep the prior state so the remaining changes are planned again
+		d.Partial(true)
+		return diag.FromErr(err)
+	}
+
+	d.SetId(storeID)
+	d.Set("added", added)
+	d.Set("changed", changed)
+	d.Set("removed", len(deletes))
+
+	return resourceDataStoreSeedRead(ctx, d, m)
+}
+
+func resourceDataStoreSeedRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	storeID := d.Id()
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Reading Appmixer data store seed", map[string]interface{}{
+		"store_id": storeID,
+	})
+
+	current, err := fetchDataStoreValues(ctx, client, storeID)
+	if err != nil {
+		if strings.Contains(err.Error(), "status 404") {
+			tflog.Warn(ctx, "Data store not found, removing seed from state", map[string]interface{}{"store_id": storeID})
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	// Items changed outside of Terraform change the hash, so the next plan re-seeds
+	d.Set("items_hash", dataStoreItemsHash(current))
+	d.Set("item_count", len(current))
+
+	return diags
+}
+
+func resourceDataStoreSeedDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	storeID := d.Id()
+	var diags diag.Diagnostics
+
+	current, err := fetchDataStoreValues(ctx, client, storeID)
+	if err != nil {
+		if strings.Contains(err.Error(), "status 404") {
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	_, deletes := diffDataStoreItems(current, nil)
+
+	tflog.Info(ctx, "Deleting seeded Appmixer data store items", map[string]interface{}{
+		"store_id": storeID,
+		"count":    len(deletes),
+	})
+
+	if err := applyDataStoreItemChanges(ctx, client, storeID, nil, deletes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,303 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var flowSharePermissions = []string{"read", "start", "stop", "use"}
+
+// Attributes of a share block identifying who the flow is shared with
+var flowShareMatchers = []string{"user_id", "email", "scope", "domain", "vendor"}
+
+func resourceFlowShare() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceFlowShareCreate,
+		ReadContext:   resourceFlowShareRead,
+		UpdateContext: resourceFlowShareUpdate,
+		DeleteContext: resourceFlowShareDelete,
+		CustomizeDiff: resourceFlowShareCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceFlowShareImport, // Import using [ownerUserId@]flowId
+		},
+		Schema: map[string]*schema.Schema{
+			"flow_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the flow to share.",
+			},
+			"owner_user_id": ownerUserIDSchema("flow share list"),
+			"share": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Users or groups the flow is shared with. Shares not listed here are removed.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"user_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Share with the user with this ID.",
+						},
+						"email": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Share with the user with this email.",
+						},
+						"scope": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Share with all users with this scope.",
+						},
+						"domain": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Share with all users whose email belongs to this domain.",
+						},
+						"vendor": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Share with all users with this vendor.",
+						},
+						"permissions": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							MinItems:    1,
+							Description: "Granted permissions: read, start, stop or use.",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(flowSharePermissions, false),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandFlowShares converts the share blocks, each must set exactly one matcher
+func expandFlowShares(v interface{}) ([]flowShareEntry, error) {
+	shares := []flowShareEntry{}
+	set, ok := v.(*schema.Set)
+	if !ok {
+		return shares, nil
+	}
+
+	for _, raw := range set.List() {
+		share := raw.(map[string]interface{})
+
+		var matchers []string
+		for _, matcher := range flowShareMatchers {
+			if share[matcher].(string) != "" {
+				matchers = append(matchers, matcher)
+			}
+		}
+		if len(matchers) != 1 {
+			return nil, fmt.Errorf("every share must set exactly one of %s, got %d", strings.Join(flowShareMatchers, ", "), len(matchers))
+		}
+
+		permissions := make([]string, 0)
+		for _, p := range share["permissions"].(*schema.Set).List() {
+			permissions = append(permissions, p.(string))
+		}
+		sort.Strings(permissions)
+
+		shares = append(shares, flowShareEntry{
+			UserID:      share["user_id"].(string),
+			Email:       share["email"].(string),
+			Scope:       share["scope"].(string),
+			Domain:      share["domain"].(string),
+			Vendor:      share["vendor"].(string),
+			Permissions: permissions,
+		})
+	}
+
+	// Keep the request stable between runs
+	sort.Slice(shares, func(i, j int) bool {
+		return fmt.Sprint(shares[i]) < fmt.Sprint(shares[j])
+	})
+	return shares, nil
+}
+
+func flattenFlowShares(shares []flowShareEntry) []interface{} {
+	result := make([]interface{}, 0, len(shares))
+	for _, share := range shares {
+		result = append(result, map[string]interface{}{
+			"user_id":     share.UserID,
+			"email":       share.Email,
+			"scope":       share.Scope,
+			"domain":      share.Domain,
+			"vendor":      share.Vendor,
+			"permissions": share.Permissions,
+		})
+	}
+	return result
+}
+
+// resourceFlowShareCustomizeDiff checks the share blocks at plan time
+func resourceFlowShareCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	// Shares computed from other resources are only known during apply
+	if !diff.NewValueKnown("share") {
+		return nil
+	}
+	_, err := expandFlowShares(diff.Get("share"))
+	return err
+}
+
+// checkFlowShareable refuses integration templates, whose share list is managed by the
+// visibility of appmixer_integration_template
+func checkFlowShareable(ctx context.Context, client *Client, flowID string) error {
+	flow, err := fetchFlow(ctx, client, flowID)
+	if err != nil {
+		return err
+	}
+	if isTemplate, _ := flow.CustomFields["template"].(bool); isTemplate {
+		return fmt.Errorf("flow %s is an integration template, set visibility of its appmixer_integration_template instead", flowID)
+	}
+	return nil
+}
+
+func saveFlowShares(ctx context.Context, client *Client, flowID string, shares []flowShareEntry) error {
+	if err := updateFlow(ctx, client, flowID, map[string]interface{}{"sharedWith": shares}); err != nil {
+		return fmt.Errorf("failed to update share list: %w", err)
+	}
+	return nil
+}
+
+func resourceFlowShareCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	flowID := d.Get("flow_id").(string)
+
+	shares, err := expandFlowShares(d.Get("share"))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := checkFlowShareable(ctx, client, flowID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, "Sharing Appmixer flow", map[string]interface{}{
+		"flow_id": flowID,
+		"shares":  len(shares),
+	})
+
+	if err := saveFlowShares(ctx, client, flowID, shares); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(flowID)
+
+	return resourceFlowShareRead(ctx, d, m)
+}
+
+func resourceFlowShareRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	flowID := d.Id()
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Reading Appmixer flow share list", map[string]interface{}{
+		"flow_id": flowID,
+	})
+
+	flow, err := fetchFlow(ctx, client, flowID)
+	if err != nil {
+		if strings.Contains(err.Error(), "status 404") {
+			tflog.Warn(ctx, "Flow not found, removing share list from state", map[string]interface{}{"flow_id": flowID})
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set("flow_id", flowID)
+	if err := d.Set("share", flattenFlowShares(flow.SharedWith)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set share: %w", err))
+	}
+
+	return diags
+}
+
+func resourceFlowShareUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	flowID := d.Id()
+
+	if d.HasChange("share") {
+		shares, err := expandFlowShares(d.Get("share"))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		oldShares, newShares := d.GetChange("share")
+		tflog.Info(ctx, "Updating Appmixer flow share list", map[string]interface{}{
+			"flow_id": flowID,
+			"added":   newShares.(*schema.Set).Difference(oldShares.(*schema.Set)).Len(),
+			"removed": oldShares.(*schema.Set).Difference(newShares.(*schema.Set)).Len(),
+		})
+
+		if err := checkFlowShareable(ctx, client, flowID); err != nil {
+			return diag.FromErr(err)
+		}
+
+		// The whole list is replaced, which adds and removes shares in one request
+		if err := saveFlowShares(ctx, client, flowID, shares); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceFlowShareRead(ctx, d, m)
+}
+
+func resourceFlowShareDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	flowID := d.Id()
+	var diags diag.Diagnostics
+
+	tflog.Info(ctx, "Removing all shares of Appmixer flow", map[string]interface{}{
+		"flow_id": flowID,
+	})
+
+	if err := saveFlowShares(ctx, client, flowID, []flowShareEntry{}); err != nil {
+		// Allow delete to succeed if the flow is already gone
+		if strings.Contains(err.Error(), "status 404") {
+			tflog.Warn(ctx, "Flow already deleted", map[string]interface{}{"flow_id": flowID})
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceFlowShareImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id, err := splitOwnerImportID(d, "owner_user_id")
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set("flow_id", id); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,576 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// How descriptor changes are applied to a running instance
+var instanceUpdateStrategies = []string{"fail", "restart", "replace"}
+
+func resourceIntegrationInstance() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIntegrationInstanceCreate,
+		ReadContext:   resourceIntegrationInstanceRead,
+		UpdateContext: resourceIntegrationInstanceUpdate,
+		DeleteContext: resourceIntegrationInstanceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIntegrationInstanceImport, // Import using [userId@]flowId
+		},
+		CustomizeDiff: resourceIntegrationInstanceCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"template_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The flow ID of the integration template to instantiate.",
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The Appmixer user ID the instance is created for and managed as. Requires admin permissions. Defaults to the authenticated user.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the instance. Defaults to the name of the template.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the instance.",
+			},
+			"thumbnail": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The thumbnail of the instance, as an image URL or data URI.",
+			},
+			"custom_fields": flowCustomFieldsSchema(),
+			"accounts": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Accounts preconfigured in the instance, keyed by component ID of the template descriptor.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"start": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the instance is running. Instances started or stopped outside of Terraform are reported as drift.",
+			},
+			"update_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "restart",
+				ValidateFunc: validation.StringInSlice(instanceUpdateStrategies, false),
+				Description:  "How account changes are applied while the instance is running: fail, restart (stop, update and start again) or replace (create a new instance).",
+			},
+			// Computed fields read from the API
+			"stage": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The stage of the instance flow (e.g., 'running' or 'stopped').",
+			},
+			"webhooks": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Public URLs of the webhook trigger components, keyed by component ID. Only set while the instance is running.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"webhooks_by_label": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Public URLs of the webhook trigger components, keyed by component label. Components without a label or sharing it with another webhook are left out. Only set while the instance is running.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"webhook_components": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Webhook trigger components of the instance. Only set while the instance is running.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"component_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"label": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"async": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the webhook responds before the flow processes the request.",
+						},
+						"methods": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "HTTP methods accepted by the webhook. Empty when the component does not restrict them.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// applyInstanceAccounts assigns accounts to the components of a descriptor. Components
+// that are not in the descriptor are reported as an error.
+func applyInstanceAccounts(descriptor map[string]interface{}, accounts map[string]interface{}) error {
+	componentIDs := make([]string, 0, len(accounts))
+	for componentID := range accounts {
+		componentIDs = append(componentIDs, componentID)
+	}
+	sort.Strings(componentIDs)
+
+	for _, componentID := range componentIDs {
+		component, ok := descriptor[componentID].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("component %s of accounts is not in the template descriptor", componentID)
+		}
+		component["accountId"] = accounts[componentID].(string)
+	}
+	return nil
+}
+
+// flattenInstanceAccounts returns the accounts of the descriptor components tracked in prior
+func flattenInstanceAccounts(descriptor map[string]interface{}, prior map[string]interface{}) map[string]string {
+	accounts := make(map[string]string)
+	for componentID := range prior {
+		component, ok := descriptor[componentID].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if accountID, ok := component["accountId"].(string); ok && accountID != "" {
+			accounts[componentID] = accountID
+		}
+	}
+	return accounts
+}
+
+// instanceClient returns the client acting as the user the instance belongs to
+func instanceClient(ctx context.Context, d *schema.ResourceData, m interface{}) (*Client, error) {
+	return m.(*Client).ForUser(ctx, d.Get("user_id").(string))
+}
+
+func expandInstanceCustomFields(d *schema.ResourceData, templateID string) (map[string]interface{}, error) {
+	return expandFlowCustomFields(d.Get("custom_fields"), map[string]interface{}{
+		"templateId":  templateID,
+		"description": d.Get("description").(string),
+	})
+}
+
+func setFlowStage(ctx context.Context, client *Client, flowID string, start bool) error {
+	command := "stop"
+	if start {
+		command = "start"
+	}
+	if _, err := client.DoRequest(ctx, "PATCH", fmt.Sprintf("/flows/%s/coordinator", flowID), map[string]string{"command": command}); err != nil {
+		return fmt.Errorf("failed to %s flow %s: %w", command, flowID, err)
+	}
+	return nil
+}
+
+// resourceIntegrationInstanceCustomizeDiff replaces running instances whose descriptor
+// changes when the update strategy is replace
+func resourceIntegrationInstanceCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	if diff.Id() == "" || diff.Get("update_strategy").(string) != "replace" {
+		return nil
+	}
+	if diff.HasChange("accounts") && diff.Get("stage").(string) == "running" {
+		return diff.ForceNew("accounts")
+	}
+	return nil
+}
+
+func resourceIntegrationInstanceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := instanceClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	templateID := d.Get("template_id").(string)
+
+	template, err := fetchFlow(ctx, client, templateID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to read integration template: %w", err))
+	}
+
+	var descriptor map[string]interface{}
+	if err := json.Unmarshal(template.Flow, &descriptor); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to parse descriptor of integration template %s: %w", templateID, err))
+	}
+	if err := applyInstanceAccounts(descriptor, d.Get("accounts").(map[string]interface{})); err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("name").(string)
+	if name == "" {
+		name = template.Name
+	}
+
+	customFields, err := expandInstanceCustomFields(d, templateID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	body := map[string]interface{}{
+		"name":         name,
+		"flow":         descriptor,
+		"customFields": customFields,
+		"thumbnail":    d.Get("thumbnail").(string),
+	}
+	if len(template.Wizard) > 0 && string(template.Wizard) != "null" {
+		body["wizard"] = template.Wizard
+	}
+
+	tflog.Info(ctx, "Creating Appmixer integration instance", map[string]interface{}{
+		"template_id": templateID,
+		"user_id":     client.UserID,
+	})
+
+	flowID, err := createFlow(ctx, client, body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(flowID)
+
+	if d.Get("start").(bool) {
+		if err := setFlowStage(ctx, client, flowID, true); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceIntegrationInstanceRead(ctx, d, m)
+}
+
+func resourceIntegrationInstanceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := instanceClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	flowID := d.Id()
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Reading Appmixer integration instance", map[string]interface{}{
+		"flow_id": flowID,
+	})
+
+	flow, err := fetchFlow(ctx, client, flowID)
+	if err != nil {
+		if strings.Contains(err.Error(), "status 404") {
+			tflog.Warn(ctx, "Integration instance not found, removing from state", map[string]interface{}{"flow_id": flowID})
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	var descriptor map[string]interface{}
+	if err := json.Unmarshal(flow.Flow, &descriptor); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to parse descriptor of integration instance %s: %w", flowID, err))
+	}
+
+	if templateID, ok := flow.CustomFields["templateId"].(string); ok {
+		d.Set("template_id", templateID)
+	}
+	d.Set("user_id", flow.UserID)
+	description, _ := flow.CustomFields["description"].(string)
+	customFields, err := flattenFlowCustomFields(flow.CustomFields, d.Get("custom_fields").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", flow.Name)
+	d.Set("description", description)
+	d.Set("thumbnail", flow.Thumbnail)
+	if err := d.Set("custom_fields", customFields); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set custom_fields: %w", err))
+	}
+	d.Set("stage", flow.Stage)
+	// Reconcile start with flows started or stopped outside of Terraform, so the plan
+	// shows when the configuration starts or stops the instance
+	if flow.Stage == "running" || flow.Stage == "stopped" {
+		d.Set("start", flow.Stage == "running")
+	}
+	if err := d.Set("accounts", flattenInstanceAccounts(descriptor, d.Get("accounts").(map[string]interface{}))); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set accounts: %w", err))
+	}
+
+	// Webhook URLs only accept requests while the flow is running
+	webhooks := []flowWebhook{}
+	if flow.Stage == "running" {
+		if webhooks, err = fetchFlowWebhooks(ctx, client, flowID, descriptor); err != nil {
+			// Keep the webhooks of the last refresh, they only change with the descriptor
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Failed to read webhooks of integration instance %s", flowID),
+				Detail:   fmt.Sprintf("The webhooks of the last refresh are kept: %s", err),
+			})
+			return diags
+		}
+	}
+	urls := make(map[string]string, len(webhooks))
+	labelCounts := make(map[string]int, len(webhooks))
+	webhookList := make([]map[string]interface{}, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		urls[webhook.ComponentID] = webhook.URL
+		labelCounts[webhook.Label]++
+		webhookList = append(webhookList, map[string]interface{}{
+			"component_id": webhook.ComponentID,
+			"label":        webhook.Label,
+			"type":         webhook.Type,
+			"url":          webhook.URL,
+			"async":        webhook.Async,
+			"methods":      webhook.Methods,
+		})
+	}
+	urlsByLabel := make(map[string]string, len(webhooks))
+	for _, webhook := range webhooks {
+		if webhook.Label != "" && labelCounts[webhook.Label] == 1 {
+			urlsByLabel[webhook.Label] = webhook.URL
+		}
+	}
+	d.Set("webhooks", urls)
+	d.Set("webhooks_by_label", urlsByLabel)
+	if err := d.Set("webhook_components", webhookList); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set webhook_components: %w", err))
+	}
+
+	return diags
+}
+
+func resourceIntegrationInstanceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := instanceClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	flowID := d.Id()
+
+	stoppedForUpdate := false
+	// A rename alone does not touch the descriptor, so running instances keep running
+	if d.HasChange("name") && !d.HasChange("accounts") {
+		tflog.Info(ctx, "Renaming Appmixer integration instance", map[string]interface{}{
+			"flow_id": flowID,
+		})
+
+		if err := updateFlow(ctx, client, flowID, map[string]interface{}{"name": d.Get("name").(string)}); err != nil {
+			d.Partial(true)
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("accounts") {
+		flow, err := fetchFlow(ctx, client, flowID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		var descriptor map[string]interface{}
+		if err := json.Unmarshal(flow.Flow, &descriptor); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to parse descriptor of integration instance %s: %w", flowID, err))
+		}
+
+		// Unassign accounts removed from the configuration before assigning the new ones
+		oldAccounts, newAccounts := d.GetChange("accounts")
+		for componentID := range oldAccounts.(map[string]interface{}) {
+			if component, ok := descriptor[componentID].(map[string]interface{}); ok {
+				delete(component, "accountId")
+			}
+		}
+		if err := applyInstanceAccounts(descriptor, newAccounts.(map[string]interface{})); err != nil {
+			return diag.FromErr(err)
+		}
+
+		// Appmixer does not reliably apply descriptor changes to running flows. The replace
+		// strategy never gets here for running instances, see the CustomizeDiff.
+		restart := false
+		if flow.Stage == "running" {
+			strategy := d.Get("update_strategy").(string)
+			if strategy == "fail" {
+				return diag.Errorf("Integration instance %s is running and update_strategy is fail. Stop the instance or change update_strategy to update its accounts", flowID)
+			}
+
+			tflog.Info(ctx, "Stopping Appmixer integration instance to update it", map[string]interface{}{
+				"flow_id":  flowID,
+				"strategy": strategy,
+			})
+			if err := setFlowStage(ctx, client, flowID, false); err != nil {
+				return diag.FromErr(err)
+			}
+			stoppedForUpdate = true
+			restart = d.Get("start").(bool)
+		}
+
+		tflog.Info(ctx, "Updating Appmixer integration instance", map[string]interface{}{
+			"flow_id": flowID,
+		})
+
+		body := map[string]interface{}{
+			"name": d.Get("name").(string),
+			"flow": descriptor,
+		}
+		if err := updateFlow(ctx, client, flowID, body); err != nil {
+			d.Partial(true)
+			if restart {
+				if startErr := setFlowStage(ctx, client, flowID, true); startErr != nil {
+					return diag.FromErr(fmt.Errorf("%w, restarting the previous descriptor also failed: %v", err, startErr))
+				}
+			}
+			return diag.FromErr(err)
+		}
+
+		if restart {
+			if err := restartInstance(ctx, client, flowID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				d.Partial(true)
+
+				// Go back to the descriptor that was running before the update. The start may
+				// have gone through even though waiting for it failed, so stop the flow first.
+				tflog.Warn(ctx, "Restarting updated integration instance failed, restoring previous descriptor", map[string]interface{}{
+					"flow_id": flowID,
+					"error":   err.Error(),
+				})
+				if stopErr := setFlowStage(ctx, client, flowID, false); stopErr != nil {
+					return diag.FromErr(fmt.Errorf("%w, stopping it to restore the previous descriptor also failed: %v", err, stopErr))
+				}
+				previous := map[string]interface{}{
+					"name": flow.Name,
+					"flow": flow.Flow,
+				}
+				if restoreErr := updateFlow(ctx, client, flowID, previous); restoreErr != nil {
+					return diag.FromErr(fmt.Errorf("%w, restoring the previous descriptor also failed: %v", err, restoreErr))
+				}
+				if restoreErr := restartInstance(ctx, client, flowID, d.Timeout(schema.TimeoutUpdate)); restoreErr != nil {
+					return diag.FromErr(fmt.Errorf("%w, restarting the previous descriptor also failed: %v", err, restoreErr))
+				}
+				return diag.FromErr(fmt.Errorf("%w, the previous descriptor was restored and restarted", err))
+			}
+		}
+	}
+
+	// Metadata changes do not touch the descriptor, so running instances keep running
+	if d.HasChanges("description", "thumbnail", "custom_fields") {
+		customFields, err := expandInstanceCustomFields(d, d.Get("template_id").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		flow, err := fetchFlow(ctx, client, flowID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		oldCustomFields, _ := d.GetChange("custom_fields")
+		mergeFlowCustomFields(customFields, flow.CustomFields, oldCustomFields)
+
+		tflog.Info(ctx, "Updating Appmixer integration instance metadata", map[string]interface{}{
+			"flow_id": flowID,
+		})
+
+		body := map[string]interface{}{
+			"customFields": customFields,
+			"thumbnail":    d.Get("thumbnail").(string),
+		}
+		if err := updateFlow(ctx, client, flowID, body); err != nil {
+			d.Partial(true)
+			return diag.FromErr(err)
+		}
+	}
+
+	// An instance stopped for the update stays stopped when start changed to false
+	if d.HasChange("start") && !(stoppedForUpdate && !d.Get("start").(bool)) {
+		if err := setFlowStage(ctx, client, flowID, d.Get("start").(bool)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceIntegrationInstanceRead(ctx, d, m)
+}
+
+// restartInstance starts a stopped instance and waits until it is running
+func restartInstance(ctx context.Context, client *Client, flowID string, timeout time.Duration) error {
+	if err := setFlowStage(ctx, client, flowID, true); err != nil {
+		return err
+	}
+	return waitForFlowStage(ctx, client, flowID, "running", timeout)
+}
+
+func resourceIntegrationInstanceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := instanceClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	flowID := d.Id()
+	var diags diag.Diagnostics
+
+	tflog.Info(ctx, "Deleting Appmixer integration instance", map[string]interface{}{
+		"flow_id": flowID,
+	})
+
+	if err := stopAndDeleteFlow(ctx, client, flowID, d.Get("stage").(string)); err != nil {
+		if strings.Contains(err.Error(), "status 404") {
+			tflog.Warn(ctx, "Integration instance already deleted", map[string]interface{}{"flow_id": flowID})
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceIntegrationInstanceImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Instances of other users are imported as <user_id>@<flow_id>
+	id, err := splitOwnerImportID(d, "user_id")
+	if err != nil {
+		return nil, err
+	}
+	client, err := instanceClient(ctx, d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	flow, err := fetchFlow(ctx, client, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Track all preconfigured accounts of the imported instance
+	var descriptor map[string]interface{}
+	if err := json.Unmarshal(flow.Flow, &descriptor); err != nil {
+		return nil, fmt.Errorf("failed to parse descriptor of integration instance %s: %w", d.Id(), err)
+	}
+	all := make(map[string]interface{}, len(descriptor))
+	for componentID := range descriptor {
+		all[componentID] = ""
+	}
+	d.Set("accounts", flattenInstanceAccounts(descriptor, all))
+	d.Set("start", flow.Stage == "running")
+	d.Set("update_strategy", "restart")
+
+	return []*schema.ResourceData{d}, nil
+}
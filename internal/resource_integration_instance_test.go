@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIntegrationInstanceImportWithOwner(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/u2/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"token": "token-u2"}`))
+	})
+	mux.HandleFunc("/flows/f1", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token-u2" {
+			t.Errorf("flow read with Authorization %q, want the token of the owner", got)
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{
+			"flowId": "f1",
+			"userId": "u2",
+			"name": "Alerts",
+			"stage": "running",
+			"flow": {"c1": {"type": "appmixer.slack.list.SendChannelMessage", "accountId": "a1"}, "c2": {"type": "appmixer.utils.timers.Timer"}},
+			"customFields": {"templateId": "t1"}
+		}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := &Client{ApiURL: server.URL, UserID: "admin", Scope: []string{"admin"}, HTTPClient: server.Client()}
+	d := resourceIntegrationInstance().TestResourceData()
+	d.SetId("u2@f1")
+
+	imported, err := resourceIntegrationInstanceImport(context.Background(), d, client)
+	if err != nil {
+		t.Fatalf("import failed: %s", err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("got %d imported resources, want 1", len(imported))
+	}
+
+	if got := d.Id(); got != "f1" {
+		t.Errorf("ID is %q, want f1", got)
+	}
+	if got := d.Get("user_id").(string); got != "u2" {
+		t.Errorf("user_id is %q, want u2", got)
+	}
+	if got := d.Get("start").(bool); !got {
+		t.Error("start is false for a running instance")
+	}
+	accounts := d.Get("accounts").(map[string]interface{})
+	if len(accounts) != 1 || accounts["c1"] != "a1" {
+		t.Errorf("accounts are %v, want c1 = a1", accounts)
+	}
+}
+
+func TestIntegrationInstanceImportWithoutOwner(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/flows/f1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"flowId": "f1", "userId": "admin", "stage": "stopped", "flow": {}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{ApiURL: server.URL, UserID: "admin", HTTPClient: server.Client()}
+	d := resourceIntegrationInstance().TestResourceData()
+	d.SetId("f1")
+
+	if _, err := resourceIntegrationInstanceImport(context.Background(), d, client); err != nil {
+		t.Fatalf("import failed: %s", err)
+	}
+	if got := d.Get("user_id").(string); got != "" {
+		t.Errorf("user_id is %q, want it unset", got)
+	}
+}
@@ -0,0 +1,306 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Permissions granted to the users a template is visible to, they need to read the
+// template and instantiate it
+var integrationTemplatePermissions = []string{"read", "use"}
+
+func resourceIntegrationTemplate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIntegrationTemplateCreate,
+		ReadContext:   resourceIntegrationTemplateRead,
+		UpdateContext: resourceIntegrationTemplateUpdate,
+		DeleteContext: resourceIntegrationTemplateDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importOwnedPassthrough, // Import using [ownerUserId@]flowId
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the integration shown to end users.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the integration shown to end users.",
+			},
+			"thumbnail": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The thumbnail of the integration shown to end users, as an image URL or data URI.",
+			},
+			"custom_fields": flowCustomFieldsSchema(),
+			"descriptor": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJSON,
+				Description:      "The JSON encoded flow descriptor of the template.",
+			},
+			"wizard": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJSON,
+				Description:      "The JSON encoded wizard configuration, i.e. the fields end users fill in when instantiating the template.",
+			},
+			"visibility": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Who can see and instantiate the template. Without visibility only the owner can.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"scopes": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "User scopes the template is visible to (e.g., 'user').",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"vendors": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "User vendors the template is visible to.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"owner_user_id": ownerUserIDSchema("template"),
+			// Computed fields read from the API
+			"user_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Appmixer user ID owning the template.",
+			},
+		},
+	}
+}
+
+func expandIntegrationTemplateVisibility(v interface{}) []flowShareEntry {
+	shares := []flowShareEntry{}
+	list, _ := v.([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return shares
+	}
+	visibility := list[0].(map[string]interface{})
+
+	for _, scope := range expandStringList(visibility["scopes"]) {
+		shares = append(shares, flowShareEntry{Scope: scope, Permissions: integrationTemplatePermissions})
+	}
+	for _, vendor := range expandStringList(visibility["vendors"]) {
+		shares = append(shares, flowShareEntry{Vendor: vendor, Permissions: integrationTemplatePermissions})
+	}
+	return shares
+}
+
+// mergeIntegrationTemplateShares keeps the remote shares visibility cannot express, e.g.
+// with single users added in the Appmixer UI, so updates do not remove them
+func mergeIntegrationTemplateShares(shares []flowShareEntry, remote []flowShareEntry) []flowShareEntry {
+	for _, share := range remote {
+		if share.Scope == "" && share.Vendor == "" {
+			shares = append(shares, share)
+		}
+	}
+	return shares
+}
+
+func flattenIntegrationTemplateVisibility(shares []flowShareEntry) []interface{} {
+	scopes := []string{}
+	vendors := []string{}
+	for _, share := range shares {
+		if share.Scope != "" {
+			scopes = append(scopes, share.Scope)
+		}
+		if share.Vendor != "" {
+			vendors = append(vendors, share.Vendor)
+		}
+	}
+	if len(scopes) == 0 && len(vendors) == 0 {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{
+		"scopes":  scopes,
+		"vendors": vendors,
+	}}
+}
+
+func expandIntegrationTemplate(d *schema.ResourceData) (map[string]interface{}, error) {
+	descriptor, err := decodeJSONAttribute("descriptor", d.Get("descriptor").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	customFields, err := expandFlowCustomFields(d.Get("custom_fields"), map[string]interface{}{
+		"template":    true,
+		"description": d.Get("description").(string),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"name":         d.Get("name").(string),
+		"flow":         descriptor,
+		"customFields": customFields,
+		"thumbnail":    d.Get("thumbnail").(string),
+		"sharedWith":   expandIntegrationTemplateVisibility(d.Get("visibility")),
+	}
+
+	if v, ok := d.GetOk("wizard"); ok {
+		wizard, err := decodeJSONAttribute("wizard", v.(string))
+		if err != nil {
+			return nil, err
+		}
+		body["wizard"] = wizard
+	}
+
+	return body, nil
+}
+
+func resourceIntegrationTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	body, err := expandIntegrationTemplate(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, "Creating Appmixer integration template", map[string]interface{}{
+		"name": body["name"],
+	})
+
+	flowID, err := createFlow(ctx, client, body)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(flowID)
+
+	return resourceIntegrationTemplateRead(ctx, d, m)
+}
+
+func resourceIntegrationTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	flowID := d.Id()
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Reading Appmixer integration template", map[string]interface{}{
+		"flow_id": flowID,
+	})
+
+	flow, err := fetchFlow(ctx, client, flowID)
+	if err != nil {
+		if strings.Contains(err.Error(), "status 404") {
+			tflog.Warn(ctx, "Integration template not found, removing from state", map[string]interface{}{"flow_id": flowID})
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	if isTemplate, _ := flow.CustomFields["template"].(bool); !isTemplate {
+		return diag.Errorf("Flow %s is not an integration template", flowID)
+	}
+
+	description, _ := flow.CustomFields["description"].(string)
+	customFields, err := flattenFlowCustomFields(flow.CustomFields, d.Get("custom_fields").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", flow.Name)
+	d.Set("description", description)
+	d.Set("thumbnail", flow.Thumbnail)
+	if err := d.Set("custom_fields", customFields); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set custom_fields: %w", err))
+	}
+	d.Set("descriptor", preserveJSONFormatting(d.Get("descriptor").(string), string(flow.Flow)))
+	if len(flow.Wizard) > 0 && string(flow.Wizard) != "null" {
+		d.Set("wizard", preserveJSONFormatting(d.Get("wizard").(string), string(flow.Wizard)))
+	} else {
+		d.Set("wizard", "")
+	}
+	if err := d.Set("visibility", flattenIntegrationTemplateVisibility(flow.SharedWith)); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set visibility: %w", err))
+	}
+	d.Set("user_id", flow.UserID)
+
+	return diags
+}
+
+func resourceIntegrationTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	flowID := d.Id()
+
+	if d.HasChanges("name", "description", "thumbnail", "custom_fields", "descriptor", "wizard", "visibility") {
+		body, err := expandIntegrationTemplate(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		flow, err := fetchFlow(ctx, client, flowID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		oldCustomFields, _ := d.GetChange("custom_fields")
+		mergeFlowCustomFields(body["customFields"].(map[string]interface{}), flow.CustomFields, oldCustomFields)
+		body["sharedWith"] = mergeIntegrationTemplateShares(body["sharedWith"].([]flowShareEntry), flow.SharedWith)
+
+		tflog.Info(ctx, "Updating Appmixer integration template", map[string]interface{}{
+			"flow_id": flowID,
+		})
+
+		if err := updateFlow(ctx, client, flowID, body); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceIntegrationTemplateRead(ctx, d, m)
+}
+
+func resourceIntegrationTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	flowID := d.Id()
+	var diags diag.Diagnostics
+
+	tflog.Info(ctx, "Deleting Appmixer integration template", map[string]interface{}{
+		"flow_id": flowID,
+	})
+
+	// Templates are never started, instances are separate flows and are kept
+	if err := stopAndDeleteFlow(ctx, client, flowID, ""); err != nil {
+		if strings.Contains(err.Error(), "status 404") {
+			tflog.Warn(ctx, "Integration template already deleted", map[string]interface{}{"flow_id": flowID})
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
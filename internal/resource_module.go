@@ -0,0 +1,397 @@
+package internal
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Fixed modification time for all archive entries so packaging the same sources
+// always produces byte-identical archives
+var modulePackageModTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Represents the response from POST /components
+type publishComponentResponse struct {
+	Ticket string `json:"ticket"`
+}
+
+// Represents the response from GET /components/uploader/:ticket
+type componentUploadStatus struct {
+	Finished json.RawMessage `json:"finished"`
+	Err      string          `json:"err"`
+}
+
+func (s componentUploadStatus) done() bool {
+	finished := strings.TrimSpace(string(s.Finished))
+	return finished != "" && finished != "null" && finished != "false"
+}
+
+func resourceModule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceModulePublish,
+		ReadContext:   resourceModuleRead,
+		UpdateContext: resourceModuleUpdate,
+		DeleteContext: resourceModuleDelete,
+		CustomizeDiff: resourceModuleCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceModuleImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the published service or module (e.g., 'vendor.myservice'). Used to look up and delete the module.",
+			},
+			"source_dir": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"source_dir", "source_zip"},
+				Description:  "Local directory containing the service/module (service.json, components, auth.js, package.json). It is packaged into a deterministic zip archive.",
+			},
+			"source_zip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"source_dir", "source_zip"},
+				Description:  "Local zip archive of the service/module, uploaded as-is.",
+			},
+			"excludes": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Glob patterns of paths relative to source_dir to leave out of the package (e.g., 'node_modules', '*.test.js').",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"validate_manifests": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Validate every component.json in the package offline during plan, so manifest errors are reported with their paths instead of as opaque server errors.",
+			},
+			"content_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 of the published package. A change of the sources changes the hash and re-publishes the module.",
+			},
+		},
+	}
+}
+
+// packageModuleDir zips a module directory deterministically: entries are sorted,
+// use forward slashes and carry a fixed modification time and mode.
+func packageModuleDir(dir string, excludes []string) ([]byte, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if isExcludedModulePath(rel, entry.Name(), excludes) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Type().IsRegular() {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read module directory %s: %w", dir, err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("module directory %s contains no files", dir)
+	}
+	sort.Strings(files)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, rel := range files {
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, err
+		}
+
+		header := &zip.FileHeader{
+			Name:     rel,
+			Method:   zip.Deflate,
+			Modified: modulePackageModTime,
+		}
+		header.SetMode(0644)
+
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func isExcludedModulePath(rel, name string, excludes []string) bool {
+	if name == ".git" || name == ".DS_Store" {
+		return true
+	}
+	for _, pattern := range excludes {
+		if matched, _ := path.Match(pattern, rel); matched {
+			return true
+		}
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// loadModulePackage returns the archive to upload from either source_dir or source_zip
+func loadModulePackage(sourceDir, sourceZip string, excludes []string) ([]byte, error) {
+	if sourceZip != "" {
+		content, err := os.ReadFile(sourceZip)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read module archive %s: %w", sourceZip, err)
+		}
+		return content, nil
+	}
+	return packageModuleDir(sourceDir, excludes)
+}
+
+func modulePackageHash(pkg []byte) string {
+	sum := sha256.Sum256(pkg)
+	return hex.EncodeToString(sum[:])
+}
+
+func expandStringList(v interface{}) []string {
+	raw, _ := v.([]interface{})
+	list := make([]string, 0, len(raw))
+	for _, item := range raw {
+		if s, ok := item.(string); ok {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+func resourceModuleCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	// Sources computed from other resources are only known during apply
+	if !diff.NewValueKnown("source_dir") || !diff.NewValueKnown("source_zip") || !diff.NewValueKnown("excludes") {
+		return diff.SetNewComputed("content_hash")
+	}
+
+	pkg, err := loadModulePackage(diff.Get("source_dir").(string), diff.Get("source_zip").(string), expandStringList(diff.Get("excludes")))
+	if err != nil {
+		return err
+	}
+
+	if diff.Get("validate_manifests").(bool) {
+		if err := checkModuleManifests(ctx, diff.Get("name").(string), pkg); err != nil {
+			return err
+		}
+	}
+
+	hash := modulePackageHash(pkg)
+	if hash != diff.Get("content_hash").(string) {
+		tflog.Debug(ctx, "Module sources changed, planning re-publish", map[string]interface{}{
+			"name":         diff.Get("name").(string),
+			"content_hash": hash,
+		})
+		return diff.SetNew("content_hash", hash)
+	}
+	return nil
+}
+
+// checkModuleManifests fails with all manifest errors of the package; warnings are only logged
+func checkModuleManifests(ctx context.Context, name string, pkg []byte) error {
+	issues, err := validateModulePackage(pkg)
+	if err != nil {
+		return err
+	}
+
+	errs, warnings := splitManifestIssues(issues)
+	for _, w := range warnings {
+		tflog.Warn(ctx, "Component manifest warning", map[string]interface{}{
+			"name":    name,
+			"warning": w,
+		})
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("module %s has invalid component manifests:\n  %s", name, strings.Join(errs, "\n  "))
+	}
+	return nil
+}
+
+func resourceModulePublish(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	name := d.Get("name").(string)
+
+	pkg, err := loadModulePackage(d.Get("source_dir").(string), d.Get("source_zip").(string), expandStringList(d.Get("excludes")))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hash := modulePackageHash(pkg)
+
+	tflog.Info(ctx, "Publishing Appmixer module", map[string]interface{}{
+		"name":         name,
+		"content_hash": hash,
+		"size":         len(pkg),
+	})
+
+	respBytes, err := client.DoRawRequest(ctx, "POST", "/components", "application/octet-stream", pkg)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to publish module %s: %w", name, err))
+	}
+
+	var publishRes publishComponentResponse
+	if err := json.Unmarshal(respBytes, &publishRes); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to parse publish response for module %s: %w", name, err))
+	}
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if !d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutUpdate)
+	}
+	if publishRes.Ticket != "" {
+		if err := waitForModuleUpload(ctx, client, name, publishRes.Ticket, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(name)
+	d.Set("content_hash", hash)
+
+	return resourceModuleRead(ctx, d, m)
+}
+
+// resourceModuleUpdate re-publishes the module only when the package changed. excludes and
+// validate_manifests are client-side settings that do not always change the package.
+func resourceModuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if !d.HasChange("content_hash") {
+		tflog.Debug(ctx, "Module package unchanged, skipping re-publish", map[string]interface{}{"name": d.Id()})
+		return resourceModuleRead(ctx, d, m)
+	}
+	return resourceModulePublish(ctx, d, m)
+}
+
+// waitForModuleUpload polls the uploader ticket until the server has processed the archive
+func waitForModuleUpload(ctx context.Context, client *Client, name, ticket string, timeout time.Duration) error {
+	retryDelay := 2 * time.Second
+	deadline := time.Now().Add(timeout)
+
+	for {
+		respBytes, err := client.DoRequest(ctx, "GET", fmt.Sprintf("/components/uploader/%s", ticket), nil)
+		if err != nil {
+			return fmt.Errorf("failed to check upload status of module %s: %w", name, err)
+		}
+
+		var status componentUploadStatus
+		if err := json.Unmarshal(respBytes, &status); err != nil {
+			return fmt.Errorf("failed to parse upload status of module %s: %w", name, err)
+		}
+
+		if status.Err != "" {
+			return fmt.Errorf("publishing module %s failed: %s", name, status.Err)
+		}
+		if status.done() {
+			return nil
+		}
+
+		if time.Now().Add(retryDelay).After(deadline) {
+			return fmt.Errorf("publishing module %s did not finish within %s", name, timeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+func resourceModuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	name := d.Id()
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Reading Appmixer module", map[string]interface{}{"name": name})
+
+	respBytes, err := client.DoRequest(ctx, "GET", "/apps", nil)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("failed to list apps while reading module %s: %w", name, err))
+	}
+
+	var appsData map[string]appResponse
+	if err := json.Unmarshal(respBytes, &appsData); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to parse apps response: %w", err))
+	}
+
+	if _, ok := appsData[name]; !ok {
+		tflog.Warn(ctx, "Module not found, removing from state", map[string]interface{}{"name": name})
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("name", name)
+
+	return diags
+}
+
+func resourceModuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	name := d.Id()
+	var diags diag.Diagnostics
+
+	tflog.Info(ctx, "Deleting Appmixer module", map[string]interface{}{"name": name})
+
+	_, err := client.DoRequest(ctx, "DELETE", fmt.Sprintf("/components/%s", name), nil)
+	if err != nil {
+		// Allow delete to succeed if the module is already gone
+		if strings.Contains(err.Error(), "status 404") {
+			tflog.Warn(ctx, "Module already deleted", map[string]interface{}{"name": name})
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(fmt.Errorf("failed to delete module %s: %w", name, err))
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceModuleImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("name", d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,239 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceServiceConfig() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceServiceConfigCreate,
+		ReadContext:   resourceServiceConfigRead,
+		UpdateContext: resourceServiceConfigUpdate,
+		DeleteContext: resourceServiceConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServiceConfigImport, // Import using serviceId
+		},
+		Schema: map[string]*schema.Schema{
+			"service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The service the configuration belongs to (e.g., 'appmixer:google' or 'appmixer:slack:list').",
+			},
+			"config": {
+				Type:        schema.TypeMap,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Configuration key/value pairs, e.g. 'clientId' and 'clientSecret' for OAuth services. Values are sent as strings; values stored with another type in Appmixer are read back JSON encoded.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			// Computed fields read from the API
+			"keys": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sorted configuration keys currently stored in Appmixer. Not sensitive, so drift of individual keys is visible in plans.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"drifted_keys": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sorted configuration keys whose value was changed, added or removed outside of Terraform, as found by the last refresh. Not sensitive, so the keys are visible in plans.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func serviceConfigPath(serviceID string) string {
+	return fmt.Sprintf("/service-config/%s", url.PathEscape(serviceID))
+}
+
+// expandServiceConfig builds the request body. Values are always sent as strings so
+// numeric-looking secrets such as app IDs keep their type.
+func expandServiceConfig(serviceID string, config map[string]interface{}) map[string]interface{} {
+	body := make(map[string]interface{}, len(config)+1)
+	for k, v := range config {
+		body[k] = v.(string)
+	}
+	body["serviceId"] = serviceID
+	return body
+}
+
+// flattenServiceConfig converts the stored configuration into a string map, JSON encoding
+// values that were not set as strings (e.g. through the Backoffice)
+func flattenServiceConfig(remote map[string]interface{}) map[string]string {
+	config := make(map[string]string, len(remote))
+	for k, v := range remote {
+		if k == "serviceId" || k == "_id" {
+			continue
+		}
+		if s, ok := v.(string); ok {
+			config[k] = s
+			continue
+		}
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			config[k] = fmt.Sprintf("%v", v)
+			continue
+		}
+		config[k] = string(encoded)
+	}
+	return config
+}
+
+func resourceServiceConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	serviceID := d.Get("service_id").(string)
+
+	if !hasAdminPermissions(client) {
+		return diag.Errorf("Managing service configuration requires admin permissions")
+	}
+
+	tflog.Info(ctx, "Creating Appmixer service configuration", map[string]interface{}{
+		"service_id": serviceID,
+	})
+
+	body := expandServiceConfig(serviceID, d.Get("config").(map[string]interface{}))
+	if _, err := client.DoRequest(ctx, "POST", "/service-config", body); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to create service configuration for %s: %w", serviceID, err))
+	}
+
+	d.SetId(serviceID)
+
+	return resourceServiceConfigRead(ctx, d, m)
+}
+
+func resourceServiceConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	serviceID := d.Id()
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Reading Appmixer service configuration", map[string]interface{}{
+		"service_id": serviceID,
+	})
+
+	respBytes, err := client.DoRequest(ctx, "GET", serviceConfigPath(serviceID), nil)
+	if err != nil {
+		if strings.Contains(err.Error(), "status 404") {
+			tflog.Warn(ctx, "Service configuration not found, removing from state", map[string]interface{}{"service_id": serviceID})
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(fmt.Errorf("failed to read service configuration for %s: %w", serviceID, err))
+	}
+
+	var remote map[string]interface{}
+	if err := json.Unmarshal(respBytes, &remote); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to parse service configuration for %s: %w", serviceID, err))
+	}
+
+	config := flattenServiceConfig(remote)
+
+	// Report which keys drifted, the values themselves are sensitive
+	prior := d.Get("config").(map[string]interface{})
+	drifted := []string{}
+	for k, v := range prior {
+		remoteValue, ok := config[k]
+		if !ok {
+			tflog.Warn(ctx, "Service configuration key removed outside of Terraform", map[string]interface{}{"service_id": serviceID, "key": k})
+			drifted = append(drifted, k)
+		} else if remoteValue != v.(string) {
+			tflog.Warn(ctx, "Service configuration key changed outside of Terraform", map[string]interface{}{"service_id": serviceID, "key": k})
+			drifted = append(drifted, k)
+		}
+	}
+	keys := make([]string, 0, len(config))
+	for k := range config {
+		if _, ok := prior[k]; !ok && len(prior) > 0 {
+			tflog.Warn(ctx, "Service configuration key added outside of Terraform", map[string]interface{}{"service_id": serviceID, "key": k})
+			drifted = append(drifted, k)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	sort.Strings(drifted)
+
+	if err := d.Set("service_id", serviceID); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set service_id: %w", err))
+	}
+	if err := d.Set("config", config); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set config: %w", err))
+	}
+	if err := d.Set("keys", keys); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set keys: %w", err))
+	}
+	if err := d.Set("drifted_keys", drifted); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set drifted_keys: %w", err))
+	}
+
+	return diags
+}
+
+func resourceServiceConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	serviceID := d.Id()
+
+	if !hasAdminPermissions(client) {
+		return diag.Errorf("Managing service configuration requires admin permissions")
+	}
+
+	if d.HasChange("config") {
+		tflog.Info(ctx, "Updating Appmixer service configuration", map[string]interface{}{
+			"service_id": serviceID,
+		})
+
+		// PUT replaces the whole configuration, so keys removed from the map are removed in Appmixer too
+		body := expandServiceConfig(serviceID, d.Get("config").(map[string]interface{}))
+		if _, err := client.DoRequest(ctx, "PUT", serviceConfigPath(serviceID), body); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to update service configuration for %s: %w", serviceID, err))
+		}
+	}
+
+	return resourceServiceConfigRead(ctx, d, m)
+}
+
+func resourceServiceConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	serviceID := d.Id()
+	var diags diag.Diagnostics
+
+	tflog.Info(ctx, "Deleting Appmixer service configuration", map[string]interface{}{
+		"service_id": serviceID,
+	})
+
+	_, err := client.DoRequest(ctx, "DELETE", serviceConfigPath(serviceID), nil)
+	if err != nil {
+		// Allow delete to succeed if already gone
+		if strings.Contains(err.Error(), "status 404") {
+			tflog.Warn(ctx, "Service configuration already deleted", map[string]interface{}{"service_id": serviceID})
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(fmt.Errorf("failed to delete service configuration for %s: %w", serviceID, err))
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceServiceConfigImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("service_id", d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,290 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var systemConfigValueFields = []string{"string_value", "number_value", "bool_value", "json_value"}
+
+// Represents an entry from the GET /config API
+type systemConfigEntry struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+func resourceSystemConfig() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSystemConfigCreate,
+		ReadContext:   resourceSystemConfigRead,
+		UpdateContext: resourceSystemConfigUpdate,
+		DeleteContext: resourceSystemConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSystemConfigImport, // Import using key
+		},
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The system configuration key (e.g., 'WEB_HOOK_FLOW_LIMIT').",
+			},
+			"string_value": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: systemConfigValueFields,
+				Description:  "String value of the key.",
+			},
+			"number_value": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ExactlyOneOf: systemConfigValueFields,
+				Description:  "Number value of the key.",
+			},
+			"bool_value": {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				ExactlyOneOf: systemConfigValueFields,
+				Description:  "Boolean value of the key.",
+			},
+			"json_value": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ExactlyOneOf:     systemConfigValueFields,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentJSON,
+				Description:      "JSON encoded value of the key, for objects and arrays.",
+			},
+			// Computed fields read from the API
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the stored value: string, number, bool or json.",
+			},
+		},
+	}
+}
+
+func systemConfigPath(key string) string {
+	return fmt.Sprintf("/config/%s", url.PathEscape(key))
+}
+
+// systemConfigType returns the type of a stored value as used by the typed value attributes
+func systemConfigType(value json.RawMessage) string {
+	trimmed := bytes.TrimSpace(value)
+	if len(trimmed) == 0 {
+		return "json"
+	}
+	switch trimmed[0] {
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "bool"
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return "number"
+	default:
+		return "json"
+	}
+}
+
+// expandSystemConfigValue returns the configured value with its type
+func expandSystemConfigValue(d *schema.ResourceData) (interface{}, error) {
+	if v, ok := d.GetOk("json_value"); ok {
+		var value interface{}
+		if err := json.Unmarshal([]byte(v.(string)), &value); err != nil {
+			return nil, fmt.Errorf("failed to parse json_value: %w", err)
+		}
+		return value, nil
+	}
+	// Zero values are not reported by GetOk, so fall back to what is set in the configuration
+	raw := d.GetRawConfig()
+	switch {
+	case !raw.IsNull() && !raw.GetAttr("number_value").IsNull():
+		return d.Get("number_value").(float64), nil
+	case !raw.IsNull() && !raw.GetAttr("bool_value").IsNull():
+		return d.Get("bool_value").(bool), nil
+	default:
+		return d.Get("string_value").(string), nil
+	}
+}
+
+func fetchSystemConfig(ctx context.Context, client *Client) ([]systemConfigEntry, error) {
+	respBytes, err := client.DoRequest(ctx, "GET", "/config", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read system configuration: %w", err)
+	}
+
+	var entries []systemConfigEntry
+	if err := json.Unmarshal(respBytes, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse system configuration: %w", err)
+	}
+	return entries, nil
+}
+
+func resourceSystemConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	key := d.Get("key").(string)
+
+	if !hasAdminPermissions(client) {
+		return diag.Errorf("Managing system configuration requires admin permissions")
+	}
+
+	value, err := expandSystemConfigValue(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, "Creating Appmixer system configuration key", map[string]interface{}{
+		"key": key,
+	})
+
+	body := map[string]interface{}{"key": key, "value": value}
+	if _, err := client.DoRequest(ctx, "POST", "/config", body); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set system configuration key %s: %w", key, err))
+	}
+
+	d.SetId(key)
+
+	return resourceSystemConfigRead(ctx, d, m)
+}
+
+func resourceSystemConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	key := d.Id()
+	var diags diag.Diagnostics
+
+	if !hasAdminPermissions(client) {
+		return diag.Errorf("Reading system configuration requires admin permissions")
+	}
+
+	tflog.Debug(ctx, "Reading Appmixer system configuration key", map[string]interface{}{
+		"key": key,
+	})
+
+	entries, err := fetchSystemConfig(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var entry *systemConfigEntry
+	for i := range entries {
+		if entries[i].Key == key {
+			entry = &entries[i]
+			break
+		}
+	}
+	if entry == nil {
+		tflog.Warn(ctx, "System configuration key not found, removing from state", map[string]interface{}{"key": key})
+		d.SetId("")
+		return diags
+	}
+
+	valueType := systemConfigType(entry.Value)
+	// Values configured as JSON stay in json_value whatever their type, so e.g. a quoted
+	// string in json_value does not move to string_value
+	if _, ok := d.GetOk("json_value"); ok {
+		valueType = "json"
+	}
+
+	for _, field := range systemConfigValueFields {
+		d.Set(field, nil)
+	}
+	switch valueType {
+	case "string":
+		var s string
+		if err := json.Unmarshal(entry.Value, &s); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to parse value of system configuration key %s: %w", key, err))
+		}
+		d.Set("string_value", s)
+	case "number":
+		var f float64
+		if err := json.Unmarshal(entry.Value, &f); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to parse value of system configuration key %s: %w", key, err))
+		}
+		d.Set("number_value", f)
+	case "bool":
+		var b bool
+		if err := json.Unmarshal(entry.Value, &b); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to parse value of system configuration key %s: %w", key, err))
+		}
+		d.Set("bool_value", b)
+	default:
+		d.Set("json_value", string(entry.Value))
+	}
+
+	d.Set("key", key)
+	d.Set("type", valueType)
+
+	return diags
+}
+
+func resourceSystemConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	key := d.Id()
+
+	if !hasAdminPermissions(client) {
+		return diag.Errorf("Managing system configuration requires admin permissions")
+	}
+
+	if d.HasChanges(systemConfigValueFields...) {
+		value, err := expandSystemConfigValue(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		tflog.Info(ctx, "Updating Appmixer system configuration key", map[string]interface{}{
+			"key": key,
+		})
+
+		body := map[string]interface{}{"key": key, "value": value}
+		if _, err := client.DoRequest(ctx, "POST", "/config", body); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to set system configuration key %s: %w", key, err))
+		}
+	}
+
+	return resourceSystemConfigRead(ctx, d, m)
+}
+
+func resourceSystemConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	key := d.Id()
+	var diags diag.Diagnostics
+
+	if !hasAdminPermissions(client) {
+		return diag.Errorf("Managing system configuration requires admin permissions")
+	}
+
+	tflog.Info(ctx, "Deleting Appmixer system configuration key", map[string]interface{}{
+		"key": key,
+	})
+
+	_, err := client.DoRequest(ctx, "DELETE", systemConfigPath(key), nil)
+	if err != nil {
+		// Allow delete to succeed if already gone
+		if strings.Contains(err.Error(), "status 404") {
+			tflog.Warn(ctx, "System configuration key already deleted", map[string]interface{}{"key": key})
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(fmt.Errorf("failed to delete system configuration key %s: %w", key, err))
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceSystemConfigImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("key", d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
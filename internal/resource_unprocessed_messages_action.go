@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Results of an action on a single unprocessed message
+const (
+	unprocessedMessageReplayed  = "replayed"
+	unprocessedMessageDiscarded = "discarded"
+	unprocessedMessageMissing   = "missing"
+	unprocessedMessageFailed    = "failed"
+)
+
+// resourceUnprocessedMessagesAction replays or discards a set of unprocessed messages once,
+// when it is created. Changing any argument creates it again, which runs the action again.
+func resourceUnprocessedMessagesAction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUnprocessedMessagesActionCreate,
+		ReadContext:   resourceUnprocessedMessagesActionRead,
+		DeleteContext: resourceUnprocessedMessagesActionDelete,
+		Schema: map[string]*schema.Schema{
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"replay", "discard"}, false),
+				Description:  "What to do with the messages: replay or discard.",
+			},
+			"message_ids": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				Description: "IDs of the unprocessed messages, e.g. from the appmixer_unprocessed_messages data source.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"owner_user_id": ownerUserIDSchema("unprocessed messages"),
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values that run the action again when changed.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			// Computed fields
+			"results": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Result per message ID: replayed, discarded, missing or failed.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceUnprocessedMessagesActionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := ownerClient(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	action := d.Get("action").(string)
+	messageIDs := expandStringList(d.Get("message_ids").(*schema.Set).List())
+	sort.Strings(messageIDs)
+
+	// Replaying posts the message back to the component, discarding deletes it
+	method, result := "POST", unprocessedMessageReplayed
+	if action == "discard" {
+		method, result = "DELETE", unprocessedMessageDiscarded
+	}
+
+	tflog.Info(ctx, "Processing Appmixer unprocessed messages", map[string]interface{}{
+		"action":   action,
+		"messages": len(messageIDs),
+	})
+
+	results := make(map[string]string, len(messageIDs))
+	var failures []string
+	for _, messageID := range messageIDs {
+		_, err := client.DoRequest(ctx, method, fmt.Sprintf("/unprocessed-messages/%s", url.PathEscape(messageID)), nil)
+		switch {
+		case err == nil:
+			results[messageID] = result
+		case strings.Contains(err.Error(), "status 404"):
+			// Already replayed or discarded, e.g. from the UI
+			tflog.Warn(ctx, "Unprocessed message not found", map[string]interface{}{"message_id": messageID})
+			results[messageID] = unprocessedMessageMissing
+		default:
+			results[messageID] = unprocessedMessageFailed
+			failures = append(failures, fmt.Sprintf("%s: %s", messageID, err))
+		}
+	}
+
+	sum := sha256.Sum256([]byte(action + ":" + strings.Join(messageIDs, ",")))
+	d.SetId(hex.EncodeToString(sum[:]))
+	d.Set("results", results)
+
+	if len(failures) > 0 {
+		return diag.Errorf("Failed to %s %d of %d unprocessed messages:\n  %s", action, len(failures), len(messageIDs), strings.Join(failures, "\n  "))
+	}
+
+	return resourceUnprocessedMessagesActionRead(ctx, d, m)
+}
+
+// The action has no remote state to read, results are kept as recorded at creation
+func resourceUnprocessedMessagesActionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	return diags
+}
+
+func resourceUnprocessedMessagesActionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Removing Appmixer unprocessed messages action from state", map[string]interface{}{
+		"id": d.Id(),
+	})
+
+	d.SetId("")
+	return diags
+}
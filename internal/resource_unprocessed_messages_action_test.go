@@ -0,0 +1,135 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// fakeUnprocessedMessagesServer answers requests for /unprocessed-messages/:id with the
+// status configured for the message ID, 200 by default, and records the requests
+type fakeUnprocessedMessagesServer struct {
+	statuses map[string]int
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func (f *fakeUnprocessedMessagesServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	messageID := strings.TrimPrefix(r.URL.Path, "/unprocessed-messages/")
+
+	f.mu.Lock()
+	f.requests = append(f.requests, r.Method+" "+messageID)
+	f.mu.Unlock()
+
+	status, ok := f.statuses[messageID]
+	if !ok {
+		status = http.StatusOK
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if status >= 300 {
+		w.Write([]byte(`{"message": "fake failure"}`))
+		return
+	}
+	w.Write([]byte(`{}`))
+}
+
+func runUnprocessedMessagesAction(t *testing.T, fake *fakeUnprocessedMessagesServer, action string, messageIDs ...string) (*schema.ResourceData, bool) {
+	t.Helper()
+
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	client := &Client{ApiURL: server.URL, UserID: "owner", HTTPClient: server.Client()}
+
+	ids := make([]interface{}, 0, len(messageIDs))
+	for _, id := range messageIDs {
+		ids = append(ids, id)
+	}
+	d := schema.TestResourceDataRaw(t, resourceUnprocessedMessagesAction().Schema, map[string]interface{}{
+		"action":      action,
+		"message_ids": ids,
+	})
+
+	diags := resourceUnprocessedMessagesActionCreate(context.Background(), d, client)
+	return d, diags.HasError()
+}
+
+func checkUnprocessedMessageResults(t *testing.T, d *schema.ResourceData, want map[string]string) {
+	t.Helper()
+
+	results := d.Get("results").(map[string]interface{})
+	if len(results) != len(want) {
+		t.Errorf("got %d results, want %d: %v", len(results), len(want), results)
+	}
+	for id, result := range want {
+		if results[id] != result {
+			t.Errorf("result of %s is %v, want %s", id, results[id], result)
+		}
+	}
+}
+
+func TestUnprocessedMessagesActionReplay(t *testing.T) {
+	fake := &fakeUnprocessedMessagesServer{}
+	d, failed := runUnprocessedMessagesAction(t, fake, "replay", "m2", "m1")
+	if failed {
+		t.Fatal("replay failed")
+	}
+
+	checkUnprocessedMessageResults(t, d, map[string]string{"m1": unprocessedMessageReplayed, "m2": unprocessedMessageReplayed})
+	if got, want := strings.Join(fake.requests, ","), "POST m1,POST m2"; got != want {
+		t.Errorf("requests are %q, want %q", got, want)
+	}
+	if d.Id() == "" {
+		t.Error("ID is not set")
+	}
+}
+
+func TestUnprocessedMessagesActionDiscard(t *testing.T) {
+	fake := &fakeUnprocessedMessagesServer{}
+	d, failed := runUnprocessedMessagesAction(t, fake, "discard", "m1")
+	if failed {
+		t.Fatal("discard failed")
+	}
+
+	checkUnprocessedMessageResults(t, d, map[string]string{"m1": unprocessedMessageDiscarded})
+	if got, want := strings.Join(fake.requests, ","), "DELETE m1"; got != want {
+		t.Errorf("requests are %q, want %q", got, want)
+	}
+}
+
+func TestUnprocessedMessagesActionMissing(t *testing.T) {
+	fake := &fakeUnprocessedMessagesServer{statuses: map[string]int{"gone": http.StatusNotFound}}
+	d, failed := runUnprocessedMessagesAction(t, fake, "replay", "gone", "m1")
+	if failed {
+		t.Fatal("a missing message failed the action")
+	}
+
+	checkUnprocessedMessageResults(t, d, map[string]string{"gone": unprocessedMessageMissing, "m1": unprocessedMessageReplayed})
+}
+
+func TestUnprocessedMessagesActionPartialFailure(t *testing.T) {
+	fake := &fakeUnprocessedMessagesServer{statuses: map[string]int{"m2": http.StatusInternalServerError}}
+	d, failed := runUnprocessedMessagesAction(t, fake, "discard", "m1", "m2", "m3")
+	if !failed {
+		t.Fatal("a failed message did not fail the action")
+	}
+
+	// Every message is tried and the results are kept for the ones that went through
+	checkUnprocessedMessageResults(t, d, map[string]string{
+		"m1": unprocessedMessageDiscarded,
+		"m2": unprocessedMessageFailed,
+		"m3": unprocessedMessageDiscarded,
+	})
+	if len(fake.requests) != 3 {
+		t.Errorf("got %d requests, want 3", len(fake.requests))
+	}
+	if d.Id() == "" {
+		t.Error("ID is not set after a partial failure")
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Helper function to check if user has admin permissions
@@ -35,7 +38,18 @@ func resourceUser() *schema.Resource {
 		UpdateContext: resourceUserUpdate,
 		DeleteContext: resourceUserDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceUserImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceUserV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceUserStateUpgradeV0,
+			},
 		},
 		Schema: map[string]*schema.Schema{
 			"username": {
@@ -57,12 +71,37 @@ func resourceUser() *schema.Resource {
 				Computed: true,
 			},
 			"plan": {
-				Type:     schema.TypeMap,
-				Computed: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "The plan assigned to the user. Changing it requires admin permissions.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the plan, see the appmixer_plans data source.",
+						},
+						"limits": {
+							Type:             schema.TypeMap,
+							Optional:         true,
+							DiffSuppressFunc: suppressEquivalentJSON,
+							Description:      "JSON encoded limits of the plan keyed by limit name. Configured limits override the limits of the named plan for this user, see effective_limits for all limits.",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsJSON,
+							},
+						},
+					},
 				},
 			},
+			"effective_limits": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "JSON encoded limits in effect for the user keyed by limit name, the limits of the plan merged with the configured overrides.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			"scope": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -89,6 +128,18 @@ func resourceUser() *schema.Resource {
 				Default:     false,
 				Description: "Set to true to force a password reset API call on the next apply, even if the password value in the state appears unchanged. Resets to false in the state after a successful forced update.",
 			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, destroying the user fails. Deleting a user irreversibly removes their flows, accounts and data, so the protection must be explicitly disabled and applied before the user can be destroyed.",
+			},
+			"pre_delete_summary": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, a warning listing how many flows and accounts the user owns is emitted before the user is deleted.",
+			},
 		},
 	}
 }
@@ -104,16 +155,243 @@ type createUserResponse struct {
 }
 
 type updateUserRequest struct {
-	Scope  []string `json:"scope,omitempty"`
-	Vendor []string `json:"vendor,omitempty"`
+	Scope    []string  `json:"scope,omitempty"`
+	Vendor   []string  `json:"vendor,omitempty"`
+	Plan     *userPlan `json:"plan,omitempty"`
+	Password string    `json:"password,omitempty"`
 }
 
 type deleteStatusResponse struct {
+	Ticket     string `json:"ticket,omitempty"`
 	Status     string `json:"status"`
 	StepsDone  int    `json:"stepsDone"`
 	StepsTotal int    `json:"stepsTotal"`
 }
 
+// userDeletionFinishedError is returned when a deletion ticket reached a final
+// state other than completed, so it can no longer be resumed.
+type userDeletionFinishedError struct {
+	Ticket string
+	Status string
+}
+
+func (e *userDeletionFinishedError) Error() string {
+	return fmt.Sprintf("User deletion ticket %s finished with status: %s", e.Ticket, e.Status)
+}
+
+// startUserDeletion issues the delete request and returns the ticket used to track its progress.
+func startUserDeletion(ctx context.Context, client *Client, userID string) (string, error) {
+	resp, err := client.DoRequest(ctx, "DELETE", fmt.Sprintf("/users/%s", userID), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var ticketRes struct {
+		Ticket string `json:"ticket"`
+	}
+	if err := json.Unmarshal(resp, &ticketRes); err != nil {
+		return "", err
+	}
+	if ticketRes.Ticket == "" {
+		return "", fmt.Errorf("API did not return a deletion ticket for user %s", userID)
+	}
+
+	return ticketRes.Ticket, nil
+}
+
+// findPendingUserDeletion returns the ticket of a deletion of the user that is still running
+// on the server, e.g. because an earlier destroy timed out. GET /users/:id/delete-status
+// lists the deletion tickets of the user, as a single object or a list. An empty ticket
+// means there is nothing to resume, also on Appmixer versions without the endpoint.
+func findPendingUserDeletion(ctx context.Context, client *Client, userID string) (string, error) {
+	resp, err := client.DoRequest(ctx, "GET", fmt.Sprintf("/users/%s/delete-status", userID), nil)
+	if err != nil {
+		if strings.Contains(err.Error(), "status 404") || strings.Contains(err.Error(), "status 405") {
+			return "", nil
+		}
+		return "", err
+	}
+
+	var tickets []deleteStatusResponse
+	if err := json.Unmarshal(resp, &tickets); err != nil {
+		var single deleteStatusResponse
+		if err := json.Unmarshal(resp, &single); err != nil {
+			return "", fmt.Errorf("failed to parse deletion tickets of user %s: %w", userID, err)
+		}
+		tickets = []deleteStatusResponse{single}
+	}
+
+	for _, ticket := range tickets {
+		switch ticket.Status {
+		case "completed", "failed", "cancelled", "":
+			continue
+		}
+		if ticket.Ticket != "" {
+			return ticket.Ticket, nil
+		}
+	}
+	return "", nil
+}
+
+// waitForUserDeletion polls the deletion ticket until it completes, fails or the timeout expires.
+// If ctx is cancelled (e.g. the apply was interrupted) the ticket is cancelled on the server.
+func waitForUserDeletion(ctx context.Context, client *Client, userID, ticket string, timeout time.Duration) error {
+	statusURL := fmt.Sprintf("/users/%s/delete-status/%s", userID, ticket)
+	retryDelay := 2 * time.Second
+	deadline := time.Now().Add(timeout)
+
+	var lastStatus deleteStatusResponse
+	for {
+		resp, err := client.DoRequest(ctx, "GET", statusURL, nil)
+		if err != nil {
+			if ctx.Err() != nil {
+				return cancelUserDeletion(client, userID, ticket)
+			}
+			return err
+		}
+
+		if err := json.Unmarshal(resp, &lastStatus); err != nil {
+			return err
+		}
+
+		tflog.Info(ctx, "User deletion in progress", map[string]interface{}{
+			"user_id":     userID,
+			"ticket":      ticket,
+			"status":      lastStatus.Status,
+			"steps_done":  lastStatus.StepsDone,
+			"steps_total": lastStatus.StepsTotal,
+		})
+
+		switch lastStatus.Status {
+		case "completed":
+			return nil
+		case "failed", "cancelled":
+			return &userDeletionFinishedError{Ticket: ticket, Status: lastStatus.Status}
+		}
+
+		if time.Now().Add(retryDelay).After(deadline) {
+			return fmt.Errorf("User deletion did not finish within %s (status: %s, %d/%d steps done). "+
+				"The deletion of ticket %s continues on the server, the next destroy resumes waiting for it",
+				timeout, lastStatus.Status, lastStatus.StepsDone, lastStatus.StepsTotal, ticket)
+		}
+
+		// Wait before checking again
+		select {
+		case <-ctx.Done():
+			return cancelUserDeletion(client, userID, ticket)
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+// cancelUserDeletion cancels a pending deletion ticket. It uses a fresh context because
+// the one passed to the operation has already been cancelled.
+func cancelUserDeletion(client *Client, userID, ticket string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tflog.Warn(ctx, "User deletion interrupted, cancelling ticket", map[string]interface{}{
+		"user_id": userID,
+		"ticket":  ticket,
+	})
+
+	_, err := client.DoRequest(ctx, "DELETE", fmt.Sprintf("/users/%s/delete-status/%s", userID, ticket), nil)
+	if err != nil {
+		return fmt.Errorf("User deletion was interrupted and cancelling ticket %s failed: %w", ticket, err)
+	}
+
+	return &userDeletionFinishedError{Ticket: ticket, Status: "cancelled"}
+}
+
+// ownedResourcesSummary holds the number of flows and accounts owned by a user.
+// A count of -1 means the API could not be queried for it.
+type ownedResourcesSummary struct {
+	Flows    int
+	Accounts int
+}
+
+func (s ownedResourcesSummary) String() string {
+	format := func(n int) string {
+		if n < 0 {
+			return "unknown"
+		}
+		return fmt.Sprintf("%d", n)
+	}
+	return fmt.Sprintf("%s flow(s), %s account(s)", format(s.Flows), format(s.Accounts))
+}
+
+// importWithDeletionProtection imports a resource by ID and initialises the
+// deletion_protection attribute so the first plan after import is empty.
+func importWithDeletionProtection(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("deletion_protection", false); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceUserImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("pre_delete_summary", false); err != nil {
+		return nil, err
+	}
+	return importWithDeletionProtection(ctx, d, m)
+}
+
+// findUserIDByUsername looks up the ID of a user by exact username match.
+// An empty ID with a nil error means no such user exists.
+func findUserIDByUsername(ctx context.Context, client *Client, username string) (string, error) {
+	usersResp, err := client.DoRequest(ctx, "GET", fmt.Sprintf("/users?pattern=%s", url.QueryEscape(username)), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var usersRes []userResponse
+	if err := json.Unmarshal(usersResp, &usersRes); err != nil {
+		return "", err
+	}
+
+	for _, user := range usersRes {
+		if user.Username == username {
+			return user.ID, nil
+		}
+	}
+
+	return "", nil
+}
+
+// fetchOwnedResourcesSummary counts the flows and accounts owned by a user.
+// Failures are logged and reported as unknown counts rather than aborting the caller.
+func fetchOwnedResourcesSummary(ctx context.Context, client *Client, userID string) ownedResourcesSummary {
+	summary := ownedResourcesSummary{Flows: -1, Accounts: -1}
+
+	flowsResp, err := client.DoRequest(ctx, "GET", fmt.Sprintf("/flows/count?filter=userId:%s", userID), nil)
+	if err != nil {
+		tflog.Warn(ctx, "Failed to count flows owned by user", map[string]interface{}{
+			"user_id": userID,
+			"error":   err.Error(),
+		})
+	} else {
+		var countData countResponse
+		if err := json.Unmarshal(flowsResp, &countData); err == nil {
+			summary.Flows = countData.Count
+		}
+	}
+
+	accountsResp, err := client.DoRequest(ctx, "GET", fmt.Sprintf("/accounts?filter=userId:%s", userID), nil)
+	if err != nil {
+		tflog.Warn(ctx, "Failed to list accounts owned by user", map[string]interface{}{
+			"user_id": userID,
+			"error":   err.Error(),
+		})
+	} else {
+		var accountsList []accountResponse
+		if err := json.Unmarshal(accountsResp, &accountsList); err == nil {
+			summary.Accounts = len(accountsList)
+		}
+	}
+
+	return summary
+}
+
 func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Client)
 
@@ -160,27 +438,13 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 	// The Appmixer API doesn't return the user ID in the create response,
 	// So we need to make a separate request to fetch the user by username
 	// We'll use admin APIs for this
-	usersResp, err := client.DoRequest(ctx, "GET", fmt.Sprintf("/users?pattern=%s", d.Get("username").(string)), nil)
+	userID, err := findUserIDByUsername(ctx, client, username)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var usersRes []userResponse
-	if err := json.Unmarshal(usersResp, &usersRes); err != nil {
-		return diag.FromErr(err)
-	}
-
-	// Find the user we just created
-	var userID string
-	for _, user := range usersRes {
-		if user.Username == d.Get("username").(string) {
-			userID = user.ID
-			break
-		}
-	}
-
 	if userID == "" {
-		return diag.Errorf("Failed to find newly created user with username %s", d.Get("username").(string))
+		return diag.Errorf("Failed to find newly created user with username %s", username)
 	}
 
 	d.SetId(userID)
@@ -212,8 +476,14 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 			updateReq.Vendor = vendor
 		}
 
+		plan, err := expandUserPlan(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		updateReq.Plan = plan
+
 		// Only update if we have something to update
-		if len(updateReq.Scope) > 0 || len(updateReq.Vendor) > 0 {
+		if len(updateReq.Scope) > 0 || len(updateReq.Vendor) > 0 || updateReq.Plan != nil {
 			_, err := client.DoRequest(ctx, "PUT", fmt.Sprintf("/users/%s", userID), updateReq)
 			if err != nil {
 				return diag.FromErr(err)
@@ -263,18 +533,26 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 	d.Set("email", userRes.Email)
 	d.Set("is_active", userRes.IsActive)
 
-	// Handle plan as a map
-	if userRes.Plan != nil {
-		var planMap map[string]interface{}
-		if err := json.Unmarshal(userRes.Plan, &planMap); err == nil {
-			d.Set("plan", planMap)
-		} else {
-			// If it's not an object, try string
-			var planStr string
-			if err := json.Unmarshal(userRes.Plan, &planStr); err == nil {
-				d.Set("plan", map[string]interface{}{"name": planStr})
+	// Only the configured limits are tracked in plan, all limits are in effective_limits
+	plan := flattenUserPlan(userRes.Plan)
+	effectiveLimits := map[string]interface{}{}
+	if len(plan) > 0 {
+		planMap := plan[0].(map[string]interface{})
+		effectiveLimits = planMap["limits"].(map[string]interface{})
+		prior, _ := d.Get("plan.0.limits").(map[string]interface{})
+		limits := make(map[string]interface{}, len(prior))
+		for k := range prior {
+			if v, ok := effectiveLimits[k]; ok {
+				limits[k] = v
 			}
 		}
+		planMap["limits"] = limits
+	}
+	if err := d.Set("plan", plan); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set plan: %w", err))
+	}
+	if err := d.Set("effective_limits", effectiveLimits); err != nil {
+		return diag.FromErr(fmt.Errorf("failed to set effective_limits: %w", err))
 	}
 
 	d.Set("scope", userRes.Scope)
@@ -299,11 +577,12 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		"is_self":          userID == client.UserID,
 		"scope_changed":    d.HasChange("scope"),
 		"vendor_changed":   d.HasChange("vendor"),
+		"plan_changed":     d.HasChange("plan"),
 		"password_changed": d.HasChange("password"),
 	})
 
 	// Prevent modifying your own permissions
-	if userID == client.UserID && (d.HasChange("scope") || d.HasChange("vendor")) {
+	if userID == client.UserID && (d.HasChange("scope") || d.HasChange("vendor") || d.HasChange("plan")) {
 		return diag.Errorf("Modifying your own permissions is not allowed for security reasons")
 	}
 
@@ -335,8 +614,16 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		updateReq.Vendor = vendor
 	}
 
+	if d.HasChange("plan") {
+		plan, err := expandUserPlan(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		updateReq.Plan = plan
+	}
+
 	// Only make update request if there are fields to update
-	if len(updateReq.Scope) > 0 || len(updateReq.Vendor) > 0 {
+	if len(updateReq.Scope) > 0 || len(updateReq.Vendor) > 0 || updateReq.Plan != nil {
 		// Make the API request to update the user
 		_, err := client.DoRequest(ctx, "PUT", fmt.Sprintf("/users/%s", userID), updateReq)
 		if err != nil {
@@ -390,49 +677,90 @@ func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.Errorf("Deleting users requires admin permissions")
 	}
 
-	// Make the API request to delete the user
-	resp, err := client.DoRequest(ctx, "DELETE", fmt.Sprintf("/users/%s", userID), nil)
-	if err != nil {
-		return diag.FromErr(err)
+	// Refuse to delete protected users, telling the operator what would be lost
+	if d.Get("deletion_protection").(bool) {
+		summary := fetchOwnedResourcesSummary(ctx, client, userID)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "User is protected from deletion",
+			Detail: fmt.Sprintf("User %s owns %s which would be irreversibly removed. "+
+				"Set deletion_protection = false and apply before destroying this user.", userID, summary),
+		}}
 	}
 
-	// Parse the ticket response
-	var ticketRes struct {
-		Ticket string `json:"ticket"`
+	if d.Get("pre_delete_summary").(bool) {
+		summary := fetchOwnedResourcesSummary(ctx, client, userID)
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Deleting user together with owned resources",
+			Detail:   fmt.Sprintf("User %s owned %s at the time of deletion.", userID, summary),
+		})
 	}
-	if err := json.Unmarshal(resp, &ticketRes); err != nil {
-		return diag.FromErr(err)
+
+	// State is not saved when a destroy fails, so a deletion left running by an earlier
+	// destroy is looked up on the server and resumed instead of starting a new one
+	ticket, err := findPendingUserDeletion(ctx, client, userID)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if ticket != "" {
+		tflog.Info(ctx, "Resuming pending user deletion", map[string]interface{}{
+			"user_id": userID,
+			"ticket":  ticket,
+		})
+	} else if ticket, err = startUserDeletion(ctx, client, userID); err != nil {
+		return append(diags, diag.FromErr(err)...)
 	}
 
-	// Poll the delete status until completed or failed
-	ticket := ticketRes.Ticket
-	maxRetries := 30
-	retryDelay := 2 * time.Second
+	if err := waitForUserDeletion(ctx, client, userID, ticket, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
 
-	for i := 0; i < maxRetries; i++ {
-		statusURL := fmt.Sprintf("/users/%s/delete-status/%s", userID, ticket)
-		resp, err := client.DoRequest(ctx, "GET", statusURL, nil)
-		if err != nil {
-			return diag.FromErr(err)
-		}
+	d.SetId("")
+	return diags
+}
 
-		var statusRes deleteStatusResponse
-		if err := json.Unmarshal(resp, &statusRes); err != nil {
-			return diag.FromErr(err)
-		}
+// resourceUserV0 is the schema of appmixer_user before plan became a nested block.
+// It is only used to decode state written by older provider versions.
+func resourceUserV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"username":              {Type: schema.TypeString, Required: true},
+			"email":                 {Type: schema.TypeString, Required: true},
+			"password":              {Type: schema.TypeString, Required: true, Sensitive: true},
+			"is_active":             {Type: schema.TypeBool, Computed: true},
+			"plan":                  {Type: schema.TypeMap, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"scope":                 {Type: schema.TypeList, Optional: true, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"vendor":                {Type: schema.TypeList, Optional: true, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"created":               {Type: schema.TypeString, Computed: true},
+			"password_force_update": {Type: schema.TypeBool, Optional: true},
+			"deletion_protection":   {Type: schema.TypeBool, Optional: true},
+			"pre_delete_summary":    {Type: schema.TypeBool, Optional: true},
+		},
+	}
+}
 
-		if statusRes.Status == "completed" {
-			break
-		}
+// resourceUserStateUpgradeV0 converts the flattened plan map into the nested plan block.
+// Limits were never stored faithfully in the map, they are filled in by the next read.
+func resourceUserStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
 
-		if statusRes.Status == "failed" || statusRes.Status == "cancelled" {
-			return diag.Errorf("User deletion failed with status: %s", statusRes.Status)
+	planBlock := []interface{}{}
+	if plan, ok := rawState["plan"].(map[string]interface{}); ok {
+		if name, ok := plan["name"].(string); ok && name != "" {
+			planBlock = append(planBlock, map[string]interface{}{
+				"name":   name,
+				"limits": map[string]interface{}{},
+			})
 		}
-
-		// Wait before checking again
-		time.Sleep(retryDelay)
 	}
+	rawState["plan"] = planBlock
 
-	d.SetId("")
-	return diags
+	tflog.Debug(ctx, "Upgraded appmixer_user state to version 1", map[string]interface{}{
+		"plan_entries": len(planBlock),
+	})
+
+	return rawState, nil
 }
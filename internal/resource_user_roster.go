@@ -0,0 +1,580 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// rosterUserSpec is a single user entry of an appmixer_user_roster
+type rosterUserSpec struct {
+	Username string
+	Email    string
+	Password string
+	Scope    []string
+	Vendor   []string
+}
+
+// rosterResult is the outcome of reconciling one roster user
+type rosterResult struct {
+	Username string
+	UserID   string
+	Err      error
+}
+
+func resourceUserRoster() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUserRosterCreate,
+		ReadContext:   resourceUserRosterRead,
+		UpdateContext: resourceUserRosterUpdate,
+		DeleteContext: resourceUserRosterDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"user": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "Users managed by this roster, matched by username. Existing Appmixer users with the same username are only adopted when adopt_existing is true.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"password": {
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+						"scope": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"vendor": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, existing Appmixer users with a configured username are adopted into the roster and get the configured password, scope and vendor. When false, such users fail to provision.",
+			},
+			"concurrency": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     5,
+				Description: "Maximum number of users created, updated or deleted in parallel.",
+			},
+			"batch_size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     100,
+				Description: "Number of users fetched per request when listing existing users.",
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, destroying the roster or removing users from it fails.",
+			},
+			"user_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Map of username to Appmixer user ID for all successfully provisioned users.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"failed_users": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Map of username to error message for users that could not be reconciled during the last apply.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func expandRosterUsers(v interface{}) map[string]rosterUserSpec {
+	specs := make(map[string]rosterUserSpec)
+	for _, raw := range v.(*schema.Set).List() {
+		u := raw.(map[string]interface{})
+		spec := rosterUserSpec{
+			Username: u["username"].(string),
+			Email:    u["email"].(string),
+			Password: u["password"].(string),
+		}
+		for _, s := range u["scope"].([]interface{}) {
+			spec.Scope = append(spec.Scope, s.(string))
+		}
+		for _, v := range u["vendor"].([]interface{}) {
+			spec.Vendor = append(spec.Vendor, v.(string))
+		}
+		specs[spec.Username] = spec
+	}
+	return specs
+}
+
+func flattenRosterUsers(specs map[string]rosterUserSpec) []interface{} {
+	users := make([]interface{}, 0, len(specs))
+	for _, spec := range specs {
+		users = append(users, map[string]interface{}{
+			"username": spec.Username,
+			"email":    spec.Email,
+			"password": spec.Password,
+			"scope":    spec.Scope,
+			"vendor":   spec.Vendor,
+		})
+	}
+	return users
+}
+
+// listAllUsers pages through /users and returns all users keyed by username
+func listAllUsers(ctx context.Context, client *Client, pageSize int) (map[string]userResponseExtended, error) {
+	users := make(map[string]userResponseExtended)
+	for offset := 0; ; offset += pageSize {
+		path := "/users?limit=" + strconv.Itoa(pageSize) + "&offset=" + strconv.Itoa(offset)
+		resp, err := client.DoRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users: %w", err)
+		}
+
+		var page []userResponseExtended
+		if err := json.Unmarshal(resp, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse users response: %w", err)
+		}
+
+		for _, u := range page {
+			users[u.Username] = u
+		}
+
+		if len(page) < pageSize {
+			return users, nil
+		}
+	}
+}
+
+// runRosterOperations runs op for every username with at most concurrency operations in flight.
+// Failures of individual users are returned in the results and never abort the others.
+func runRosterOperations(ctx context.Context, usernames []string, concurrency int, op func(ctx context.Context, username string) (string, error)) []rosterResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]rosterResult, len(usernames))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, username := range usernames {
+		wg.Add(1)
+		go func(i int, username string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			userID, err := op(ctx, username)
+			results[i] = rosterResult{Username: username, UserID: userID, Err: err}
+		}(i, username)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// provisionRosterUser creates the user, or adopts an existing one with the same username
+// when adopt is set, and applies the configured scope and vendor.
+func provisionRosterUser(ctx context.Context, client *Client, spec rosterUserSpec, existing map[string]userResponseExtended, adopt bool) (string, error) {
+	if len(spec.Password) < 5 {
+		return "", fmt.Errorf("password must be at least 5 characters long according to Appmixer requirements")
+	}
+
+	updateReq := updateUserRequest{Scope: spec.Scope, Vendor: spec.Vendor}
+	userID := ""
+	if u, ok := existing[spec.Username]; ok {
+		// The user may be managed elsewhere, adopting it hands its deletion to this roster
+		if !adopt {
+			return "", fmt.Errorf("a user with username %s already exists (ID %s), set adopt_existing = true to manage it in this roster", spec.Username, u.ID)
+		}
+		if u.ID == client.UserID {
+			return "", fmt.Errorf("adopting your own account into a roster is not allowed for security reasons")
+		}
+		if u.Email != spec.Email {
+			return "", fmt.Errorf("existing user %s has email %s instead of the configured %s", spec.Username, u.Email, spec.Email)
+		}
+
+		tflog.Info(ctx, "Adopting existing Appmixer user into roster", map[string]interface{}{
+			"username": spec.Username,
+			"user_id":  u.ID,
+		})
+		userID = u.ID
+		// The state stores the configured password, so the adopted user must have it
+		updateReq.Password = spec.Password
+	} else {
+		createReq := createUserRequest{
+			Email:    spec.Email,
+			Username: spec.Username,
+			Password: spec.Password,
+		}
+		if _, err := client.DoRequest(ctx, "POST", "/user", createReq); err != nil {
+			return "", err
+		}
+
+		var err error
+		userID, err = findUserIDByUsername(ctx, client, spec.Username)
+		if err != nil {
+			return "", err
+		}
+		if userID == "" {
+			return "", fmt.Errorf("failed to find newly created user with username %s", spec.Username)
+		}
+	}
+
+	if len(updateReq.Scope) > 0 || len(updateReq.Vendor) > 0 || updateReq.Password != "" {
+		if _, err := client.DoRequest(ctx, "PUT", fmt.Sprintf("/users/%s", userID), updateReq); err != nil {
+			return userID, err
+		}
+	}
+
+	return userID, nil
+}
+
+// updateRosterUser applies the differences between two specs of the same user
+func updateRosterUser(ctx context.Context, client *Client, userID string, oldSpec, newSpec rosterUserSpec) error {
+	if oldSpec.Email != newSpec.Email {
+		return fmt.Errorf("changing the email of an existing user is not supported (from %s to %s)", oldSpec.Email, newSpec.Email)
+	}
+
+	updateReq := updateUserRequest{}
+	if !reflect.DeepEqual(oldSpec.Scope, newSpec.Scope) {
+		updateReq.Scope = newSpec.Scope
+	}
+	if !reflect.DeepEqual(oldSpec.Vendor, newSpec.Vendor) {
+		updateReq.Vendor = newSpec.Vendor
+	}
+	if len(updateReq.Scope) > 0 || len(updateReq.Vendor) > 0 {
+		if _, err := client.DoRequest(ctx, "PUT", fmt.Sprintf("/users/%s", userID), updateReq); err != nil {
+			return err
+		}
+	}
+
+	if oldSpec.Password != newSpec.Password {
+		passwordChangeReq := struct {
+			Email    string `json:"email"`
+			Password string `json:"password"`
+		}{
+			Email:    newSpec.Email,
+			Password: newSpec.Password,
+		}
+		if _, err := client.DoRequest(ctx, "POST", "/user/reset-password", passwordChangeReq); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteRosterUser deletes a user through the delete-ticket workflow
+func deleteRosterUser(ctx context.Context, client *Client, userID string, timeout time.Duration) error {
+	if userID == client.UserID {
+		return fmt.Errorf("deleting your own account through Terraform is not allowed for security reasons")
+	}
+
+	// Resume a deletion left running by an earlier destroy, see resourceUserDelete
+	ticket, err := findPendingUserDeletion(ctx, client, userID)
+	if err != nil {
+		return err
+	}
+	if ticket == "" {
+		if ticket, err = startUserDeletion(ctx, client, userID); err != nil {
+			if strings.Contains(err.Error(), "status 404") {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return waitForUserDeletion(ctx, client, userID, ticket, timeout)
+}
+
+// rosterDiagnostics turns per-user failures into warnings and records them in failedUsers
+func rosterDiagnostics(results []rosterResult, action string, failedUsers map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, r := range results {
+		if r.Err == nil {
+			continue
+		}
+		failedUsers[r.Username] = r.Err.Error()
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Failed to %s roster user %s", action, r.Username),
+			Detail:   r.Err.Error() + ". The user is retried on the next apply.",
+		})
+	}
+	return diags
+}
+
+func sortedUsernames(specs map[string]rosterUserSpec) []string {
+	usernames := make([]string, 0, len(specs))
+	for username := range specs {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+	return usernames
+}
+
+func resourceUserRosterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	if !hasAdminPermissions(client) {
+		return diag.Errorf("Managing a user roster requires admin permissions")
+	}
+
+	specs := expandRosterUsers(d.Get("user"))
+	tflog.Info(ctx, "Creating Appmixer user roster", map[string]interface{}{
+		"users": len(specs),
+	})
+
+	existing, err := listAllUsers(ctx, client, d.Get("batch_size").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	results := runRosterOperations(ctx, sortedUsernames(specs), d.Get("concurrency").(int), func(ctx context.Context, username string) (string, error) {
+		return provisionRosterUser(ctx, client, specs[username], existing, d.Get("adopt_existing").(bool))
+	})
+
+	userIDs := make(map[string]string)
+	failedUsers := make(map[string]string)
+	diags := rosterDiagnostics(results, "provision", failedUsers)
+	for _, r := range results {
+		if r.Err == nil {
+			userIDs[r.Username] = r.UserID
+		} else {
+			// Keep failed users out of the state so the next plan retries them
+			delete(specs, r.Username)
+		}
+	}
+
+	d.SetId(id.UniqueId())
+	d.Set("user", flattenRosterUsers(specs))
+	d.Set("user_ids", userIDs)
+	d.Set("failed_users", failedUsers)
+
+	return diags
+}
+
+func resourceUserRosterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+	var diags diag.Diagnostics
+
+	existing, err := listAllUsers(ctx, client, d.Get("batch_size").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	specs := expandRosterUsers(d.Get("user"))
+	userIDs := make(map[string]string)
+	for username, spec := range specs {
+		remote, ok := existing[username]
+		if !ok {
+			tflog.Warn(ctx, "Roster user not found, removing from state", map[string]interface{}{
+				"username": username,
+			})
+			delete(specs, username)
+			continue
+		}
+
+		userIDs[username] = remote.ID
+		spec.Email = remote.Email
+		// Only track scope and vendor drift for users that configure them,
+		// Appmixer assigns a default scope otherwise
+		if len(spec.Scope) > 0 {
+			spec.Scope = remote.Scope
+		}
+		if len(spec.Vendor) > 0 {
+			spec.Vendor = remote.Vendor
+		}
+		specs[username] = spec
+	}
+
+	if err := d.Set("user", flattenRosterUsers(specs)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("user_ids", userIDs)
+
+	return diags
+}
+
+func resourceUserRosterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	if !hasAdminPermissions(client) {
+		return diag.Errorf("Managing a user roster requires admin permissions")
+	}
+
+	if !d.HasChange("user") {
+		return resourceUserRosterRead(ctx, d, m)
+	}
+
+	oldRaw, newRaw := d.GetChange("user")
+	oldSpecs := expandRosterUsers(oldRaw)
+	newSpecs := expandRosterUsers(newRaw)
+
+	userIDs := make(map[string]string)
+	for k, v := range d.Get("user_ids").(map[string]interface{}) {
+		userIDs[k] = v.(string)
+	}
+
+	added := make(map[string]rosterUserSpec)
+	changed := make(map[string]rosterUserSpec)
+	removed := make(map[string]rosterUserSpec)
+	for username, spec := range newSpecs {
+		oldSpec, ok := oldSpecs[username]
+		if !ok {
+			added[username] = spec
+		} else if !reflect.DeepEqual(oldSpec, spec) {
+			changed[username] = spec
+		}
+	}
+	for username, spec := range oldSpecs {
+		if _, ok := newSpecs[username]; !ok {
+			removed[username] = spec
+		}
+	}
+
+	if len(removed) > 0 && d.Get("deletion_protection").(bool) {
+		return diag.Errorf("Roster is protected from deletion, cannot remove %d user(s). Set deletion_protection = false and apply before removing users", len(removed))
+	}
+
+	tflog.Info(ctx, "Updating Appmixer user roster", map[string]interface{}{
+		"added":   len(added),
+		"changed": len(changed),
+		"removed": len(removed),
+	})
+
+	concurrency := d.Get("concurrency").(int)
+	failedUsers := make(map[string]string)
+	var diags diag.Diagnostics
+
+	// The state starts from the previous roster and each successful operation moves it towards the new one
+	stateSpecs := oldSpecs
+
+	if len(removed) > 0 {
+		timeout := d.Timeout(schema.TimeoutUpdate)
+		results := runRosterOperations(ctx, sortedUsernames(removed), concurrency, func(ctx context.Context, username string) (string, error) {
+			return "", deleteRosterUser(ctx, client, userIDs[username], timeout)
+		})
+		diags = append(diags, rosterDiagnostics(results, "delete", failedUsers)...)
+		for _, r := range results {
+			if r.Err == nil {
+				delete(stateSpecs, r.Username)
+				delete(userIDs, r.Username)
+			}
+		}
+	}
+
+	if len(added) > 0 {
+		existing, err := listAllUsers(ctx, client, d.Get("batch_size").(int))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		results := runRosterOperations(ctx, sortedUsernames(added), concurrency, func(ctx context.Context, username string) (string, error) {
+			return provisionRosterUser(ctx, client, added[username], existing, d.Get("adopt_existing").(bool))
+		})
+		diags = append(diags, rosterDiagnostics(results, "provision", failedUsers)...)
+		for _, r := range results {
+			if r.Err == nil {
+				stateSpecs[r.Username] = added[r.Username]
+				userIDs[r.Username] = r.UserID
+			}
+		}
+	}
+
+	if len(changed) > 0 {
+		results := runRosterOperations(ctx, sortedUsernames(changed), concurrency, func(ctx context.Context, username string) (string, error) {
+			return userIDs[username], updateRosterUser(ctx, client, userIDs[username], oldSpecs[username], changed[username])
+		})
+		diags = append(diags, rosterDiagnostics(results, "update", failedUsers)...)
+		for _, r := range results {
+			if r.Err == nil {
+				stateSpecs[r.Username] = changed[r.Username]
+			}
+		}
+	}
+
+	d.Set("user", flattenRosterUsers(stateSpecs))
+	d.Set("user_ids", userIDs)
+	d.Set("failed_users", failedUsers)
+
+	return diags
+}
+
+func resourceUserRosterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Client)
+
+	if !hasAdminPermissions(client) {
+		return diag.Errorf("Managing a user roster requires admin permissions")
+	}
+
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("Roster %s is protected from deletion. Set deletion_protection = false and apply before destroying it", d.Id())
+	}
+
+	userIDs := make(map[string]string)
+	for k, v := range d.Get("user_ids").(map[string]interface{}) {
+		userIDs[k] = v.(string)
+	}
+
+	usernames := make([]string, 0, len(userIDs))
+	for username := range userIDs {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+
+	tflog.Info(ctx, "Deleting Appmixer user roster", map[string]interface{}{
+		"users": len(usernames),
+	})
+
+	timeout := d.Timeout(schema.TimeoutDelete)
+	results := runRosterOperations(ctx, usernames, d.Get("concurrency").(int), func(ctx context.Context, username string) (string, error) {
+		return "", deleteRosterUser(ctx, client, userIDs[username], timeout)
+	})
+
+	var diags diag.Diagnostics
+	for _, r := range results {
+		if r.Err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Failed to delete roster user %s", r.Username),
+				Detail:   r.Err.Error(),
+			})
+		}
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return diags
+}